@@ -0,0 +1,46 @@
+package autoconfigure
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// ModuleDefaults are the default properties a module contributes to the environment, as YAML.
+type ModuleDefaults struct {
+	Name  string
+	Order int
+	Data  []byte
+}
+
+var moduleDefaults = []*ModuleDefaults{}
+
+// RegisterDefaults adds the default properties of a module, usually an embedded YAML file registered from the init
+// function of the package which reads them:
+//
+//	//go:embed gorm.yaml
+//	var gormDefaults []byte
+//
+//	func init() {
+//		autoconfigure.RegisterDefaults("gorm", autoconfigure.GORM_ORDER, gormDefaults)
+//	}
+//
+// The defaults of every registered module are merged into the environment, whether the module is auto-configured or
+// not, so its conditions can use them. Any config file, environment variable or argument overrides them.
+func RegisterDefaults(name string, order int, data []byte) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	moduleDefaults = append(moduleDefaults, &ModuleDefaults{Name: name, Order: order, Data: data})
+}
+
+// Defaults returns the registered module defaults in the order they are merged: by ascending order and then by name,
+// so when two modules set the same property the one with the greater order wins, whatever the registration order is.
+func Defaults() []*ModuleDefaults {
+	mutex.Lock()
+	defer mutex.Unlock()
+	sorted := slices.Clone(moduleDefaults)
+	slices.SortStableFunc(sorted, func(a, b *ModuleDefaults) int {
+		return cmp.Or(cmp.Compare(a.Order, b.Order), strings.Compare(a.Name, b.Name))
+	})
+	return sorted
+}
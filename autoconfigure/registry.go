@@ -0,0 +1,67 @@
+package autoconfigure
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/sjexpos/goboot/condition"
+)
+
+// Orders of the goboot modules, the datasource comes first since gorm and tx only apply when there is a database.
+const (
+	DATASOURCE_ORDER = 100
+	GORM_ORDER       = 200
+	TX_ORDER         = 300
+	WEB_ORDER        = 400
+	MANAGEMENT_ORDER = 500
+)
+
+// AutoConfiguration is a module which goboot.Run adds to the application by itself, when its conditions match
+// and it is not listed in goboot.autoconfigure.exclude.
+type AutoConfiguration struct {
+	*condition.Option
+	Order int
+}
+
+func (a *AutoConfiguration) GetOrder() int {
+	return a.Order
+}
+
+var (
+	mutex              sync.Mutex
+	autoConfigurations = []*AutoConfiguration{}
+)
+
+// Register adds module to the auto-configurations, usually from the init function of the package which
+// declares it. Auto-configurations are evaluated by ascending order, so a module whose bean conditions need
+// the types of another one must have a greater order. The extra conditions are only checked when the module
+// is auto-configured, e.g. a datasource is only auto-configured when datasource.host is set:
+//
+//	func init() {
+//		autoconfigure.Register(autoconfigure.DATASOURCE_ORDER, DatasourceModule, condition.OnProperty("datasource.host", "", false))
+//	}
+func Register(order int, module *condition.Option, conditions ...condition.Condition) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	autoConfigurations = append(autoConfigurations, &AutoConfiguration{
+		Option: &condition.Option{
+			Option:     module.Option,
+			Name:       module.Name,
+			Conditions: append(slices.Clone(module.Conditions), conditions...),
+		},
+		Order: order,
+	})
+}
+
+// AutoConfigurations returns the registered auto-configurations sorted by order, the ones with the same order
+// keep their registration order.
+func AutoConfigurations() []*AutoConfiguration {
+	mutex.Lock()
+	defer mutex.Unlock()
+	sorted := slices.Clone(autoConfigurations)
+	slices.SortStableFunc(sorted, func(a, b *AutoConfiguration) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+	return sorted
+}
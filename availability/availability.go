@@ -0,0 +1,54 @@
+package availability
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// ReadinessState tells whether the application accepts traffic, it is published by the readiness probe.
+type ReadinessState string
+
+const (
+	ACCEPTING_TRAFFIC ReadinessState = "ACCEPTING_TRAFFIC"
+	REFUSING_TRAFFIC  ReadinessState = "REFUSING_TRAFFIC"
+)
+
+const (
+	STATUS_UP             = "UP"
+	STATUS_OUT_OF_SERVICE = "OUT_OF_SERVICE"
+)
+
+// ApplicationAvailability holds the readiness of the application: it refuses traffic until the application
+// is ready and again once a shutdown was requested, so load balancers stop routing requests before the servers stop.
+type ApplicationAvailability struct {
+	readiness atomic.Value
+}
+
+func NewApplicationAvailability() *ApplicationAvailability {
+	availability := &ApplicationAvailability{}
+	availability.readiness.Store(REFUSING_TRAFFIC)
+	return availability
+}
+
+func (a *ApplicationAvailability) GetReadinessState() ReadinessState {
+	return a.readiness.Load().(ReadinessState)
+}
+
+func (a *ApplicationAvailability) SetReadinessState(state ReadinessState) {
+	a.readiness.Store(state)
+}
+
+// ReadinessHandler answers the readiness probe, {"status":"UP"} while the application accepts traffic and
+// {"status":"OUT_OF_SERVICE"} with a 503 otherwise.
+func (a *ApplicationAvailability) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, code := STATUS_UP, http.StatusOK
+		if a.GetReadinessState() != ACCEPTING_TRAFFIC {
+			status, code = STATUS_OUT_OF_SERVICE, http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]string{"status": status})
+	})
+}
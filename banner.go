@@ -0,0 +1,123 @@
+package goboot
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mbndr/figlet4go"
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/viper"
+)
+
+const application_banner_mode_property_name = "application.banner-mode"
+const application_banner_location_property_name = "application.banner-location"
+const application_banner_font_property_name = "application.banner-font"
+const banner_default_location = "banner.txt"
+const banner_default_font = "standard"
+const banner_mode_console = "console"
+const banner_mode_off = "off"
+const banner_mode_log = "log"
+
+var bannerPlaceholderPattern = regexp.MustCompile(`\$\{([^}:]+)(?::([^}]*))?\}`)
+
+// SetBanner makes the application print the banner.txt file of fsys (e.g. an embed.FS), it is used
+// when the file of application.banner-location does not exist.
+func (app *GobootApplication) SetBanner(fsys fs.FS) {
+	app.bannerFS = fsys
+}
+
+// printBanner prints the banner according to application.banner-mode: to the standard output (console),
+// as an info log (log) or not at all (off).
+func (app *GobootApplication) printBanner(v *viper.Viper, profiles environment.Profiles) {
+	mode := strings.ToLower(v.GetString(application_banner_mode_property_name))
+	if mode == banner_mode_off {
+		return
+	}
+	banner := app.banner(v, profiles)
+	if mode == banner_mode_log {
+		slog.Info("\n" + banner)
+		return
+	}
+	fmt.Print(banner)
+}
+
+// banner returns the banner.txt of application.banner-location (./banner.txt by default) or of the
+// banner file system, with its placeholders resolved, or the application.banner text rendered with figlet.
+func (app *GobootApplication) banner(v *viper.Viper, profiles environment.Profiles) string {
+	location := banner_default_location
+	if v.IsSet(application_banner_location_property_name) {
+		location = v.GetString(application_banner_location_property_name)
+	}
+	data, err := os.ReadFile(location)
+	if err != nil && v.IsSet(application_banner_location_property_name) {
+		slog.Warn(fmt.Sprintf("Banner %v was not found, %v", location, err))
+	}
+	if err != nil && app.bannerFS != nil {
+		data, err = fs.ReadFile(app.bannerFS, banner_default_location)
+	}
+	if err == nil {
+		return resolveBannerPlaceholders(string(data), v, profiles, app.gobootVersion())
+	}
+	bannerText := banner_default_text
+	if v.IsSet(application_banner_property_name) {
+		bannerText = v.GetString(application_banner_property_name)
+	}
+	banner := strings.Builder{}
+	banner.WriteString(renderFiglet(bannerText, v.GetString(application_banner_font_property_name)))
+	banner.WriteString(fmt.Sprintf("  :: %v ::        (%v)\n\n", libraryName, app.gobootVersion()))
+	return banner.String()
+}
+
+// resolveBannerPlaceholders replaces ${goboot.version}, ${profiles} and ${property:default} in the banner,
+// e.g. ${application.name} or ${application.version}.
+func resolveBannerPlaceholders(banner string, v *viper.Viper, profiles environment.Profiles, gobootVersion string) string {
+	return bannerPlaceholderPattern.ReplaceAllStringFunc(banner, func(placeholder string) string {
+		matches := bannerPlaceholderPattern.FindStringSubmatch(placeholder)
+		key, defaultValue := strings.TrimSpace(matches[1]), matches[2]
+		switch key {
+		case "goboot.version":
+			return gobootVersion
+		case "profiles":
+			return profiles.String()
+		}
+		if v.IsSet(key) {
+			return v.GetString(key)
+		}
+		return defaultValue
+	})
+}
+
+// renderFiglet renders text with a builtin font (standard or larry3d) or the .flf file at font.
+func renderFiglet(text string, font string) string {
+	ascii := figlet4go.NewAsciiRender()
+	options := figlet4go.NewRenderOptions()
+	options.FontName = banner_default_font
+	if font != "" {
+		options.FontName = font
+	}
+	if strings.HasSuffix(font, ".flf") {
+		options.FontName = strings.TrimSuffix(filepath.Base(font), ".flf")
+		if err := loadFigletFont(ascii, font, options.FontName); err != nil {
+			slog.Warn(fmt.Sprintf("Banner font %v was not loaded, %v", font, err))
+			options.FontName = banner_default_font
+		}
+	}
+	rendered, err := ascii.RenderOpts(text, options)
+	if err != nil {
+		return text + "\n"
+	}
+	return rendered
+}
+
+func loadFigletFont(ascii *figlet4go.AsciiRender, fileName string, fontName string) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	return ascii.LoadBindataFont(data, fontName)
+}
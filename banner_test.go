@@ -0,0 +1,36 @@
+package goboot
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBannerFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GOBOOT_PROFILES_ACTIVE", "dev")
+	writeConfigFile(t, "application.yaml", "application:\n  name: orders\n")
+	writeConfigFile(t, "banner.txt", "${application.name} ${application.version:unknown} on ${goboot.version} [${profiles}]\n")
+
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+
+	if banner := app.banner(v, profiles); banner != "orders unknown on "+libraryVersion+" [dev]\n" {
+		t.Errorf("Unexpected banner %q", banner)
+	}
+}
+
+func TestEmbeddedBannerAndFiglet(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+
+	if banner := app.banner(v, profiles); !strings.Contains(banner, ":: "+libraryName+" ::") {
+		t.Errorf("The figlet banner should be rendered without banner.txt: %q", banner)
+	}
+	app.SetBanner(fstest.MapFS{"banner.txt": {Data: []byte("embedded ${goboot.version}")}})
+	if banner := app.banner(v, profiles); banner != "embedded "+libraryVersion {
+		t.Errorf("Unexpected embedded banner %q", banner)
+	}
+}
@@ -0,0 +1,138 @@
+package beans
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Bean is a value provided to the fx container.
+type Bean struct {
+	Type         string   `json:"type"`
+	Name         string   `json:"name,omitempty"`
+	Group        string   `json:"group,omitempty"`
+	Constructor  string   `json:"constructor"`
+	Module       string   `json:"module,omitempty"`
+	Private      bool     `json:"private,omitempty"`
+	Dependencies []string `json:"dependencies"`
+}
+
+// Key identifies the bean like the fx graph does, e.g. *sql.DB, int[name=server.port] or int[group=listeners].
+func (b *Bean) Key() string {
+	switch {
+	case b.Name != "":
+		return fmt.Sprintf("%v[name=%v]", b.Type, b.Name)
+	case b.Group != "":
+		return fmt.Sprintf("%v[group=%v]", b.Type, b.Group)
+	}
+	return b.Type
+}
+
+// Registry holds the beans of the application, they are recorded by the fx event logger and their dependencies
+// come from the DOT graph of the container (fx.DotGraph).
+type Registry struct {
+	mutex sync.Mutex
+	beans []*Bean
+	graph string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// typeNamePattern splits the type names of fx events, e.g. int[name = "server.port"] or int[group = "listeners"].
+var typeNamePattern = regexp.MustCompile(`^(.+)\[(name|group) = "(.*)"\]$`)
+
+// Record adds the beans of a constructor, typeNames are the fx names of its results.
+func (r *Registry) Record(constructor string, module string, private bool, typeNames []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, typeName := range typeNames {
+		bean := &Bean{Type: typeName, Constructor: constructor, Module: module, Private: private, Dependencies: []string{}}
+		if matches := typeNamePattern.FindStringSubmatch(typeName); matches != nil {
+			bean.Type = matches[1]
+			if matches[2] == "name" {
+				bean.Name = matches[3]
+			} else {
+				bean.Group = matches[3]
+			}
+		}
+		r.beans = append(r.beans, bean)
+	}
+}
+
+var (
+	clusterPattern = regexp.MustCompile(`^\s*subgraph cluster_(\d+) \{`)
+	resultPattern  = regexp.MustCompile(`^\s*"(.+)" \[label=`)
+	edgePattern    = regexp.MustCompile(`^\s*constructor_(\d+) -> "(.+?)"`)
+	groupPattern   = regexp.MustCompile(`^\[type=(.+) group=(.+)\]$`)
+	groupIndex     = regexp.MustCompile(`\]\d+$`)
+)
+
+// SetGraph keeps the DOT graph and sets the dependencies of the beans from its edges.
+func (r *Registry) SetGraph(graph string) {
+	results := map[string][]string{}
+	dependencies := map[string][]string{}
+	cluster := ""
+	for _, line := range strings.Split(graph, "\n") {
+		if matches := clusterPattern.FindStringSubmatch(line); matches != nil {
+			cluster = matches[1]
+		} else if matches := edgePattern.FindStringSubmatch(line); matches != nil {
+			dependencies[matches[1]] = append(dependencies[matches[1]], graphKey(matches[2]))
+		} else if matches := resultPattern.FindStringSubmatch(line); matches != nil && cluster != "" {
+			results[graphKey(matches[1])] = append(results[graphKey(matches[1])], cluster)
+		} else if strings.TrimSpace(line) == "}" {
+			cluster = ""
+		}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.graph = graph
+	// the values of a group are in the graph in the order they were provided
+	used := map[string]int{}
+	for _, bean := range r.beans {
+		key := bean.Key()
+		clusters := results[key]
+		if used[key] < len(clusters) {
+			bean.Dependencies = slices.Clone(dependencies[clusters[used[key]]])
+			if bean.Dependencies == nil {
+				bean.Dependencies = []string{}
+			}
+			used[key]++
+		}
+	}
+}
+
+// graphKey turns the node of a graph into a bean key, "[type=int group=g]" and "int[group=g]0" are int[group=g].
+func graphKey(node string) string {
+	if matches := groupPattern.FindStringSubmatch(node); matches != nil {
+		return fmt.Sprintf("%v[group=%v]", matches[1], matches[2])
+	}
+	return groupIndex.ReplaceAllString(node, "]")
+}
+
+// Beans returns the beans sorted by module and type.
+func (r *Registry) Beans() []Bean {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	beans := make([]Bean, 0, len(r.beans))
+	for _, bean := range r.beans {
+		beans = append(beans, *bean)
+	}
+	slices.SortStableFunc(beans, func(a, b Bean) int {
+		if c := strings.Compare(a.Module, b.Module); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key(), b.Key())
+	})
+	return beans
+}
+
+// Graph returns the DOT graph of the container, it can be rendered e.g. with dot -Tsvg.
+func (r *Registry) Graph() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.graph
+}
@@ -0,0 +1,71 @@
+package beans
+
+import (
+	"slices"
+	"testing"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+type repository struct{}
+type service struct{}
+
+type recorder struct {
+	registry *Registry
+}
+
+func (r *recorder) LogEvent(event fxevent.Event) {
+	if provided, ok := event.(*fxevent.Provided); ok && provided.Err == nil {
+		r.registry.Record(provided.ConstructorName, provided.ModuleName, provided.Private, provided.OutputTypeNames)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	var graph fx.DotGraph
+	app := fx.New(
+		fx.WithLogger(func() fxevent.Logger { return &recorder{registry: registry} }),
+		fx.Module("users",
+			fx.Provide(
+				fx.Private,
+				func() *repository { return &repository{} },
+			),
+			fx.Provide(
+				fx.Annotate(func(*repository, string) *service { return &service{} }, fx.ParamTags(``, `name:"users.table"`)),
+				fx.Annotate(func() string { return "users" }, fx.ResultTags(`name:"users.table"`)),
+				fx.Annotate(func() int { return 1 }, fx.ResultTags(`group:"counters"`)),
+			),
+			fx.Invoke(func(*service) {}),
+		),
+		fx.Populate(&graph),
+	)
+	if err := app.Err(); err != nil {
+		t.Fatal(err)
+	}
+	registry.SetGraph(string(graph))
+
+	byKey := map[string]Bean{}
+	for _, bean := range registry.Beans() {
+		byKey[bean.Key()] = bean
+	}
+	service, found := byKey["*beans.service"]
+	if !found || service.Module != "users" {
+		t.Fatalf("The service bean was not recorded: %+v", registry.Beans())
+	}
+	if !slices.Contains(service.Dependencies, "*beans.repository") || !slices.Contains(service.Dependencies, "string[name=users.table]") {
+		t.Errorf("Unexpected dependencies of the service %v", service.Dependencies)
+	}
+	if repository := byKey["*beans.repository"]; !repository.Private {
+		t.Errorf("The repository should be private")
+	}
+	if table := byKey["string[name=users.table]"]; table.Type != "string" || table.Name != "users.table" {
+		t.Errorf("Unexpected named bean %+v", table)
+	}
+	if counter := byKey["int[group=counters]"]; counter.Group != "counters" {
+		t.Errorf("Unexpected group bean %+v", counter)
+	}
+	if registry.Graph() == "" {
+		t.Errorf("The graph was not kept")
+	}
+}
@@ -0,0 +1,155 @@
+package goboot
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/sjexpos/goboot/environment"
+	"go.uber.org/fx"
+)
+
+// ApplicationBuilder configures a GobootApplication, e.g.
+//
+//	err := goboot.New().
+//		WithProfiles("local").
+//		WithDefaultProperties(map[string]any{"server.port": 8081}).
+//		WithModules(orders.Module).
+//		Build().
+//		Run()
+//
+// Invalid settings do not panic, they are returned by the Err, Run and Start methods of the application.
+type ApplicationBuilder struct {
+	fxOpts            []fx.Option
+	arguments         []string
+	defaultProperties map[string]any
+	propertySources   []environment.PropertySource
+	profiles          []string
+	bannerMode        string
+	lazy              bool
+	bannerFS          fs.FS
+	logger            *slog.Logger
+	batch             bool
+	errs              []error
+}
+
+// New returns a builder of an application with the command-line arguments of the process.
+func New() *ApplicationBuilder {
+	return &ApplicationBuilder{defaultProperties: make(map[string]any)}
+}
+
+// WithModules adds fx options, usually the modules of the application.
+func (b *ApplicationBuilder) WithModules(fxOpts ...fx.Option) *ApplicationBuilder {
+	b.fxOpts = append(b.fxOpts, fxOpts...)
+	return b
+}
+
+// WithArguments replaces the command-line arguments of the application, see GobootApplication.SetArguments.
+func (b *ApplicationBuilder) WithArguments(args ...string) *ApplicationBuilder {
+	b.arguments = args
+	if b.arguments == nil {
+		b.arguments = []string{}
+	}
+	return b
+}
+
+// WithProfiles activates the profiles, before the ones listed in goboot.profiles.active.
+func (b *ApplicationBuilder) WithProfiles(profiles ...string) *ApplicationBuilder {
+	for _, profile := range profiles {
+		if strings.TrimSpace(profile) == "" {
+			b.errs = append(b.errs, errors.New("profile names can not be empty"))
+			continue
+		}
+		b.profiles = append(b.profiles, strings.TrimSpace(profile))
+	}
+	return b
+}
+
+// WithDefaultProperties adds properties, whose keys can be dotted (e.g. server.port), with the lowest precedence:
+// only the embedded defaults are below them, so any config file or environment variable overrides them.
+func (b *ApplicationBuilder) WithDefaultProperties(properties map[string]any) *ApplicationBuilder {
+	maps.Copy(b.defaultProperties, properties)
+	return b
+}
+
+// WithPropertySource adds a property source, which overrides the config files and is overridden by the .env file,
+// the environment variables and the command-line arguments. Sources added later override the previous ones.
+func (b *ApplicationBuilder) WithPropertySource(source environment.PropertySource) *ApplicationBuilder {
+	if source == nil || source.Name() == "" {
+		b.errs = append(b.errs, errors.New("property sources must have a name"))
+		return b
+	}
+	b.propertySources = append(b.propertySources, source)
+	return b
+}
+
+// WithBannerMode sets the default application.banner-mode: console, log or off.
+func (b *ApplicationBuilder) WithBannerMode(mode string) *ApplicationBuilder {
+	switch strings.ToLower(mode) {
+	case banner_mode_console, banner_mode_log, banner_mode_off:
+		b.bannerMode = strings.ToLower(mode)
+	default:
+		b.errs = append(b.errs, fmt.Errorf("banner mode '%v' is not one of %v, %v or %v", mode, banner_mode_console, banner_mode_log, banner_mode_off))
+	}
+	return b
+}
+
+// WithLazyInitialization sets the default goboot.lazy-initialization to true: the datasource and gorm are created
+// without connecting to the database, the first query does, so the application starts while the database is down.
+func (b *ApplicationBuilder) WithLazyInitialization() *ApplicationBuilder {
+	b.lazy = true
+	return b
+}
+
+// WithBanner sets the file system of the banner.txt, see GobootApplication.SetBanner.
+func (b *ApplicationBuilder) WithBanner(fsys fs.FS) *ApplicationBuilder {
+	b.bannerFS = fsys
+	return b
+}
+
+// WithLogger makes the application log with the logger, instead of setting up the goboot one from application.log.
+func (b *ApplicationBuilder) WithLogger(logger *slog.Logger) *ApplicationBuilder {
+	if logger == nil {
+		b.errs = append(b.errs, errors.New("logger can not be nil"))
+		return b
+	}
+	b.logger = logger
+	return b
+}
+
+// WithBatchMode makes the application run in batch mode, like RunOnce.
+func (b *ApplicationBuilder) WithBatchMode() *ApplicationBuilder {
+	b.batch = true
+	return b
+}
+
+// Build returns the application, whose Err method returns the invalid settings, if any.
+func (b *ApplicationBuilder) Build() *GobootApplication {
+	app, err := NewGobootApplication(b.fxOpts...)
+	if err != nil {
+		return &GobootApplication{err: err}
+	}
+	app.err = errors.Join(b.errs...)
+	if b.arguments != nil {
+		app.SetArguments(b.arguments...)
+	}
+	app.defaultProperties = make(map[string]any)
+	maps.Copy(app.defaultProperties, b.defaultProperties)
+	if b.bannerMode != "" {
+		app.defaultProperties[application_banner_mode_property_name] = b.bannerMode
+	}
+	if b.lazy {
+		app.defaultProperties[goboot_lazy_initialization_property_name] = true
+	}
+	app.propertySources = b.propertySources
+	app.profiles = b.profiles
+	app.bannerFS = b.bannerFS
+	app.logger = b.logger
+	if b.batch {
+		app.mode = application_mode_batch
+	}
+	return app
+}
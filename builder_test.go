@@ -0,0 +1,80 @@
+package goboot
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/sjexpos/goboot/environment"
+	"go.uber.org/fx"
+)
+
+func TestApplicationBuilder(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, "application.yaml", "server:\n  port: 8081\ngoboot:\n  profiles:\n    active: dev\n")
+	writeConfigFile(t, "application-local.yaml", "application:\n  name: local\n")
+	t.Setenv("DATASOURCE_PORT", "5433")
+
+	app := New().
+		WithArguments().
+		WithProfiles("local").
+		WithDefaultProperties(map[string]any{"server.port": 9090, "datasource.host": "db.local"}).
+		WithPropertySource(environment.NewMapPropertySource("overrides", map[string]any{"server": map[string]any{"port": 8082}, "datasource.port": 5434})).
+		WithBannerMode("off").
+		WithLazyInitialization().
+		Build()
+	if err := app.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	v, profiles, err := app.prepareEnvironment()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(profiles, environment.Profiles{"local", "dev"}) || v.GetString("application.name") != "local" {
+		t.Errorf("The local and dev profiles should be active, got %v", profiles)
+	}
+	expected := map[string][2]string{
+		"datasource.host":            {"db.local", environment.ORIGIN_DEFAULT_PROPERTIES},
+		"server.port":                {"8082", fmt.Sprintf(environment.ORIGIN_PROPERTY_SOURCE, "overrides")},
+		"datasource.port":            {"5433", "environment variable DATASOURCE_PORT"},
+		"application.banner-mode":    {"off", environment.ORIGIN_DEFAULT_PROPERTIES},
+		"goboot.lazy-initialization": {"true", environment.ORIGIN_DEFAULT_PROPERTIES},
+	}
+	for key, value := range expected {
+		if v.GetString(key) != value[0] || app.origins.Origin(key) != value[1] {
+			t.Errorf("%v should be %q from %q, got %q from %q", key, value[0], value[1], v.GetString(key), app.origins.Origin(key))
+		}
+	}
+}
+
+func TestApplicationBuilderErrors(t *testing.T) {
+	app := New().WithBannerMode("loud").WithProfiles(" ").WithLogger(nil).Build()
+	if app.Err() == nil {
+		t.Fatalf("Invalid settings should be reported")
+	}
+	if err := app.Run(); err != app.Err() {
+		t.Errorf("Run should return the invalid settings, got %v", err)
+	}
+	if _, err := app.Start(); err != app.Err() {
+		t.Errorf("Start should return the invalid settings, got %v", err)
+	}
+}
+
+func TestApplicationBuilderRunReturnsStartupErrors(t *testing.T) {
+	t.Chdir(t.TempDir())
+	failure := errors.New("boom")
+
+	err := New().
+		WithArguments().
+		WithBannerMode("off").
+		WithBatchMode().
+		WithModules(fx.Invoke(func() error { return failure })).
+		Build().
+		Run()
+
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != EXIT_CODE_FAILURE || !errors.Is(err, failure) {
+		t.Errorf("The startup error should be returned, got %v", err)
+	}
+}
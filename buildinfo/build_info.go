@@ -0,0 +1,101 @@
+package buildinfo
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Values injected at link time, they take precedence over the ones read from the binary, e.g.
+//
+//	go build -ldflags "-X github.com/sjexpos/goboot/buildinfo.Version=1.4.0 -X github.com/sjexpos/goboot/buildinfo.BuildTime=$(date -u +%FT%TZ)"
+var (
+	Version   string
+	Revision  string
+	BuildTime string
+)
+
+const GOBOOT_MODULE_PATH = "github.com/sjexpos/goboot"
+
+const develVersion = "(devel)"
+const shortRevisionLength = 7
+
+// GitInfo is the version control information stamped in the binary by go build.
+type GitInfo struct {
+	Revision string `json:"revision,omitempty"`
+	Time     string `json:"time,omitempty"`
+	Dirty    bool   `json:"dirty"`
+}
+
+func (g GitInfo) ShortRevision() string {
+	if len(g.Revision) > shortRevisionLength {
+		return g.Revision[:shortRevisionLength]
+	}
+	return g.Revision
+}
+
+// BuildInfo describes the binary: its main module, version, build time, Go version, goboot version and git commit.
+type BuildInfo struct {
+	Module        string  `json:"module,omitempty"`
+	Version       string  `json:"version,omitempty"`
+	Time          string  `json:"time,omitempty"`
+	GoVersion     string  `json:"go"`
+	GobootVersion string  `json:"goboot,omitempty"`
+	Git           GitInfo `json:"git"`
+}
+
+// Read returns the build information of the running binary.
+func Read() *BuildInfo {
+	info, _ := debug.ReadBuildInfo()
+	return newBuildInfo(info)
+}
+
+func newBuildInfo(info *debug.BuildInfo) *BuildInfo {
+	buildInfo := &BuildInfo{GoVersion: runtime.Version()}
+	if info != nil {
+		buildInfo.Module = info.Main.Path
+		if info.Main.Version != develVersion {
+			buildInfo.Version = info.Main.Version
+		}
+		buildInfo.GoVersion = info.GoVersion
+		for _, dep := range info.Deps {
+			if dep.Path == GOBOOT_MODULE_PATH {
+				buildInfo.GobootVersion = dep.Version
+			}
+		}
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				buildInfo.Git.Revision = setting.Value
+			case "vcs.time":
+				buildInfo.Git.Time = setting.Value
+			case "vcs.modified":
+				buildInfo.Git.Dirty = setting.Value == "true"
+			}
+		}
+	}
+	if Version != "" {
+		buildInfo.Version = Version
+	}
+	if Revision != "" {
+		buildInfo.Git.Revision = Revision
+	}
+	buildInfo.Time = BuildTime
+	return buildInfo
+}
+
+// String describes the version for the logs, e.g. "v1.4.0 (revision 1a2b3c4, dirty)", it is empty when nothing is known.
+func (b *BuildInfo) String() string {
+	details := []string{}
+	if b.Git.Revision != "" {
+		details = append(details, "revision "+b.Git.ShortRevision())
+	}
+	if b.Git.Dirty {
+		details = append(details, "dirty")
+	}
+	if len(details) == 0 {
+		return b.Version
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v (%v)", b.Version, strings.Join(details, ", ")))
+}
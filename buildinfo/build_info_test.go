@@ -0,0 +1,41 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestNewBuildInfo(t *testing.T) {
+	info := &debug.BuildInfo{
+		GoVersion: "go1.24.1",
+		Main:      debug.Module{Path: "example.com/orders", Version: "v1.4.0"},
+		Deps:      []*debug.Module{{Path: GOBOOT_MODULE_PATH, Version: "v0.2.0"}},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "1a2b3c4d5e6f"},
+			{Key: "vcs.time", Value: "2024-05-01T10:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	buildInfo := newBuildInfo(info)
+	if buildInfo.Module != "example.com/orders" || buildInfo.Version != "v1.4.0" || buildInfo.GobootVersion != "v0.2.0" {
+		t.Errorf("unexpected module information %+v", buildInfo)
+	}
+	if buildInfo.Git.Revision != "1a2b3c4d5e6f" || buildInfo.Git.Time != "2024-05-01T10:00:00Z" || !buildInfo.Git.Dirty {
+		t.Errorf("unexpected git information %+v", buildInfo.Git)
+	}
+	if s := buildInfo.String(); s != "v1.4.0 (revision 1a2b3c4, dirty)" {
+		t.Errorf("unexpected description %v", s)
+	}
+}
+
+func TestNewBuildInfoWithLdflags(t *testing.T) {
+	Version, Revision, BuildTime = "2.0.0", "ffff000", "2024-06-01T00:00:00Z"
+	defer func() { Version, Revision, BuildTime = "", "", "" }()
+	buildInfo := newBuildInfo(&debug.BuildInfo{Main: debug.Module{Path: "example.com/orders", Version: "(devel)"}})
+	if buildInfo.Version != "2.0.0" || buildInfo.Git.Revision != "ffff000" || buildInfo.Time != "2024-06-01T00:00:00Z" {
+		t.Errorf("ldflags values should take precedence, got %+v", buildInfo)
+	}
+	if s := newBuildInfo(nil).String(); s != "2.0.0 (revision ffff000)" {
+		t.Errorf("unexpected description %v", s)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/sjexpos/goboot/metadata"
+	"github.com/spf13/viper"
+)
+
+const defaultConfigFile = "application.yaml"
+
+// configCommand runs goboot config validate or goboot config reference.
+func configCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "validate":
+			return validateConfig(metadata.Default(), args[1:], stdout, stderr)
+		case "reference":
+			return printReference(metadata.Default(), args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprintln(stderr, "Usage: goboot config validate [application.yaml ...]")
+	fmt.Fprintln(stderr, "       goboot config reference [-json]")
+	return EXIT_CODE_USAGE
+}
+
+// validateConfig reports the problems of each file, it fails on unknown keys and type mismatches,
+// deprecated keys are only warnings.
+func validateConfig(registry *metadata.Registry, fileNames []string, stdout io.Writer, stderr io.Writer) int {
+	if len(fileNames) == 0 {
+		fileNames = []string{defaultConfigFile}
+	}
+	exitCode := 0
+	for _, fileName := range fileNames {
+		v := viper.New()
+		v.SetConfigFile(fileName)
+		if err := v.ReadInConfig(); err != nil {
+			fmt.Fprintf(stderr, "%v: %v\n", fileName, err)
+			exitCode = 1
+			continue
+		}
+		problems := registry.Validate(v.AllSettings())
+		for _, problem := range problems {
+			fmt.Fprintf(stdout, "%v: %v\n", fileName, problem)
+			if problem.Kind != metadata.PROBLEM_DEPRECATED {
+				exitCode = 1
+			}
+		}
+		if len(problems) == 0 {
+			fmt.Fprintf(stdout, "%v: OK\n", fileName)
+		}
+	}
+	return exitCode
+}
+
+func printReference(registry *metadata.Registry, args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("reference", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	asJSON := flags.Bool("json", false, "print the metadata as JSON")
+	if err := flags.Parse(args); err != nil {
+		return EXIT_CODE_USAGE
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(registry.Properties())
+		return 0
+	}
+	registry.WriteReference(stdout)
+	return 0
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sjexpos/goboot/metadata"
+)
+
+func newTestRegistry() *metadata.Registry {
+	registry := metadata.NewRegistry()
+	registry.Register(
+		metadata.Property{Key: "server.port", Type: metadata.TYPE_INT, Default: "4242", Description: "Port of the web server."},
+		metadata.Property{Key: "server.timeout", Type: metadata.TYPE_DURATION, Description: "Timeout of the requests."},
+		metadata.Property{Key: "app.old-name", Type: metadata.TYPE_STRING, Description: "Old name.", Deprecation: &metadata.Deprecation{Replacement: "application.name"}},
+	)
+	return registry
+}
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	fileName := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(fileName, []byte(content), 0o644); err != nil {
+		t.Fatalf("Cannot write %v: %v", name, err)
+	}
+	return fileName
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		exitCode int
+		output   string
+	}{
+		{name: "valid", content: "server:\n  port: 8080\n  timeout: 30s\n", exitCode: 0, output: "OK"},
+		{name: "unknown key", content: "server:\n  porrt: 8080\n", exitCode: 1, output: "server.porrt: unknown key"},
+		{name: "type mismatch", content: "server:\n  port: not a number\n", exitCode: 1, output: "server.port: type mismatch"},
+		{name: "deprecated key", content: "app:\n  old-name: users\n", exitCode: 0, output: "app.old-name: deprecated key"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := writeConfigFile(t, "application.yaml", test.content)
+			stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+			exitCode := validateConfig(newTestRegistry(), []string{fileName}, &stdout, &stderr)
+
+			if exitCode != test.exitCode {
+				t.Errorf("Expected exit code %v, got %v (%v)", test.exitCode, exitCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), fileName+": "+test.output) {
+				t.Errorf("Expected %q in the output, got %v", test.output, stdout.String())
+			}
+		})
+	}
+}
+
+func TestValidateConfigOfMissingFile(t *testing.T) {
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	fileName := filepath.Join(t.TempDir(), "application.yaml")
+
+	if exitCode := validateConfig(newTestRegistry(), []string{fileName}, &stdout, &stderr); exitCode != 1 {
+		t.Errorf("A missing file should fail, got exit code %v", exitCode)
+	}
+	if !strings.HasPrefix(stderr.String(), fileName+": ") {
+		t.Errorf("The file should be reported, got %v", stderr.String())
+	}
+}
+
+func TestRunConfigValidate(t *testing.T) {
+	valid := writeConfigFile(t, "application.yaml", "application:\n  name: users\n")
+	invalid := writeConfigFile(t, "application-dev.yaml", "application:\n  nmae: users\n")
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+	exitCode := run([]string{"config", "validate", valid, invalid}, &stdout, &stderr)
+
+	if exitCode != 1 {
+		t.Errorf("The unknown key should fail the validation, got exit code %v", exitCode)
+	}
+	if !strings.Contains(stdout.String(), valid+": OK") || !strings.Contains(stdout.String(), invalid+": application.nmae: unknown key") {
+		t.Errorf("Unexpected output %v", stdout.String())
+	}
+}
+
+func TestPrintReference(t *testing.T) {
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	if exitCode := printReference(newTestRegistry(), nil, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("Unexpected exit code %v: %v", exitCode, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "server.port (int) = 4242\n    Port of the web server.") {
+		t.Errorf("Unexpected reference %v", stdout.String())
+	}
+
+	stdout.Reset()
+	if exitCode := printReference(newTestRegistry(), []string{"-json"}, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("Unexpected exit code %v: %v", exitCode, stderr.String())
+	}
+	properties := []metadata.Property{}
+	if err := json.Unmarshal(stdout.Bytes(), &properties); err != nil || len(properties) != 3 || properties[0].Key != "app.old-name" {
+		t.Errorf("Unexpected JSON reference %v (%v)", stdout.String(), err)
+	}
+
+	if exitCode := printReference(newTestRegistry(), []string{"-yaml"}, &stdout, &stderr); exitCode != EXIT_CODE_USAGE {
+		t.Errorf("An unknown flag should print the usage, got exit code %v", exitCode)
+	}
+}
+
+func TestRunConfigReference(t *testing.T) {
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	if exitCode := run([]string{"config", "reference"}, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("Unexpected exit code %v: %v", exitCode, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "# application") || !strings.Contains(stdout.String(), "application.name (string)") {
+		t.Errorf("The registered properties should be printed, got %v", stdout.String())
+	}
+}
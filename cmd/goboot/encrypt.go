@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sjexpos/goboot/encrypt"
+)
+
+const encryptKeyEnvName = "GOBOOT_ENCRYPT_KEY"
+
+// encryptCommand runs goboot encrypt, it prints the {cipher} value of the text or a new key.
+func encryptCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags, keyFile := newKeyFlags("encrypt", stderr)
+	generateKey := flags.Bool("generate-key", false, "print a new base64 AES-256 key")
+	if err := flags.Parse(args); err != nil {
+		return EXIT_CODE_USAGE
+	}
+	if *generateKey {
+		key, err := encrypt.GenerateKey()
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		fmt.Fprintln(stdout, key)
+		return 0
+	}
+	return withEncryptor(*keyFile, flags.Args(), stdout, stderr, func(encryptor *encrypt.TextEncryptor, text string) (string, error) {
+		return encryptor.Encrypt(text)
+	})
+}
+
+// decryptCommand runs goboot decrypt, it prints the text of a {cipher} value.
+func decryptCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags, keyFile := newKeyFlags("decrypt", stderr)
+	if err := flags.Parse(args); err != nil {
+		return EXIT_CODE_USAGE
+	}
+	return withEncryptor(*keyFile, flags.Args(), stdout, stderr, func(encryptor *encrypt.TextEncryptor, value string) (string, error) {
+		return encryptor.Decrypt(value)
+	})
+}
+
+func newKeyFlags(name string, stderr io.Writer) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	keyFile := flags.String("key-file", "", "file of the base64 key, "+encryptKeyEnvName+" is used when it is not set")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: goboot %v [-key-file file] [value], the value is read from the standard input when it is not given\n", name)
+		flags.PrintDefaults()
+	}
+	return flags, keyFile
+}
+
+// withEncryptor applies f to the value of the arguments, or of the first line of the standard input so it does not
+// end up in the shell history, with the key of keyFile or GOBOOT_ENCRYPT_KEY.
+func withEncryptor(keyFile string, args []string, stdout io.Writer, stderr io.Writer, f func(encryptor *encrypt.TextEncryptor, value string) (string, error)) int {
+	var key []byte
+	var err error
+	switch {
+	case keyFile != "":
+		key, err = encrypt.ReadKeyFile(keyFile)
+	case os.Getenv(encryptKeyEnvName) != "":
+		key, err = encrypt.ParseKey(os.Getenv(encryptKeyEnvName))
+	default:
+		err = fmt.Errorf("no key, use -key-file or set %v", encryptKeyEnvName)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	encryptor, err := encrypt.NewTextEncryptor(key)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	value := strings.Join(args, " ")
+	if len(args) == 0 {
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		value = strings.TrimRight(line, "\r\n")
+	}
+	result, err := f(encryptor, value)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprintln(stdout, result)
+	return 0
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sjexpos/goboot/encrypt"
+)
+
+func writeKeyFile(t *testing.T) string {
+	t.Helper()
+	key, err := encrypt.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "goboot.key")
+	if err := os.WriteFile(keyFile, []byte(key+"\n"), 0o600); err != nil {
+		t.Fatalf("Cannot write the key file: %v", err)
+	}
+	return keyFile
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	t.Setenv(encryptKeyEnvName, "")
+	keyFile := writeKeyFile(t)
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+	if exitCode := run([]string{"encrypt", "-key-file", keyFile, "s3cr3t"}, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("Unexpected exit code %v: %v", exitCode, stderr.String())
+	}
+	encrypted := strings.TrimSpace(stdout.String())
+	if !encrypt.IsEncrypted(encrypted) || strings.Contains(encrypted, "s3cr3t") {
+		t.Fatalf("Unexpected encrypted value %v", encrypted)
+	}
+
+	stdout.Reset()
+	if exitCode := run([]string{"decrypt", "-key-file", keyFile, encrypted}, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("Unexpected exit code %v: %v", exitCode, stderr.String())
+	}
+	if text := strings.TrimSpace(stdout.String()); text != "s3cr3t" {
+		t.Errorf("Expected s3cr3t, got %v", text)
+	}
+}
+
+func TestDecryptWithWrongKey(t *testing.T) {
+	t.Setenv(encryptKeyEnvName, "")
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	if exitCode := run([]string{"encrypt", "-key-file", writeKeyFile(t), "s3cr3t"}, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("Unexpected exit code %v: %v", exitCode, stderr.String())
+	}
+	encrypted := strings.TrimSpace(stdout.String())
+
+	stdout.Reset()
+	if exitCode := run([]string{"decrypt", "-key-file", writeKeyFile(t), encrypted}, &stdout, &stderr); exitCode != 1 {
+		t.Errorf("Decrypting with another key should fail, got exit code %v", exitCode)
+	}
+	if stdout.Len() != 0 || stderr.Len() == 0 {
+		t.Errorf("Only the error should be printed, got %q and %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestEncryptWithoutKey(t *testing.T) {
+	t.Setenv(encryptKeyEnvName, "")
+	for _, name := range []string{"encrypt", "decrypt"} {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+		if exitCode := run([]string{name, "s3cr3t"}, &stdout, &stderr); exitCode != 1 {
+			t.Errorf("%v without a key should fail, got exit code %v", name, exitCode)
+		}
+		if !strings.Contains(stderr.String(), "no key") || stdout.Len() != 0 {
+			t.Errorf("%v should report the missing key, got %q", name, stderr.String())
+		}
+	}
+}
+
+func TestEncryptWithKeyFromEnvironment(t *testing.T) {
+	key, err := encrypt.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(encryptKeyEnvName, key)
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+	if exitCode := run([]string{"encrypt", "s3cr3t"}, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("Unexpected exit code %v: %v", exitCode, stderr.String())
+	}
+	encrypted := strings.TrimSpace(stdout.String())
+	stdout.Reset()
+	if exitCode := run([]string{"decrypt", encrypted}, &stdout, &stderr); exitCode != 0 || strings.TrimSpace(stdout.String()) != "s3cr3t" {
+		t.Errorf("Expected s3cr3t, got %v (%v)", stdout.String(), stderr.String())
+	}
+}
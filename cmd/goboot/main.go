@@ -0,0 +1,56 @@
+// Command goboot is the command-line tool of goboot applications:
+//
+//	goboot config validate [application.yaml ...]   validates configuration files against the property metadata
+//	goboot config reference [-json]                  prints the reference of the properties
+//	goboot encrypt [-key-file file] [value]          prints the {cipher} value of a property
+//	goboot encrypt -generate-key                     prints a new key
+//	goboot decrypt [-key-file file] [value]          prints the text of a {cipher} value
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	_ "github.com/sjexpos/goboot"
+	_ "github.com/sjexpos/goboot/supportfx"
+)
+
+const EXIT_CODE_USAGE = 2
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string, stdout io.Writer, stderr io.Writer) int
+}
+
+var commands = []command{
+	{name: "config", description: "validate configuration files or print the property reference", run: configCommand},
+	{name: "encrypt", description: "encrypt a property value, or generate a key", run: encryptCommand},
+	{name: "decrypt", description: "decrypt a {cipher} property value", run: decryptCommand},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) > 0 {
+		for _, c := range commands {
+			if c.name == args[0] {
+				return c.run(args[1:], stdout, stderr)
+			}
+		}
+		fmt.Fprintf(stderr, "unknown command %v\n", args[0])
+	}
+	usage(stderr)
+	return EXIT_CODE_USAGE
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: goboot <command> [arguments]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10v %v\n", c.name, c.description)
+	}
+}
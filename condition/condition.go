@@ -0,0 +1,147 @@
+package condition
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+// Context is what the conditions are evaluated against: the prepared environment, the active profiles
+// and the types provided by the options which are already part of the application.
+type Context struct {
+	Environment *viper.Viper
+	Profiles    environment.Profiles
+	// BeanTypes returns the names of the provided types, as fx prints them (e.g. *sql.DB or int[name = "server.port"]).
+	BeanTypes func() []string
+}
+
+// HasBean reports whether a value of the type named typeName (as returned by reflect.Type.String()) is provided.
+func (c *Context) HasBean(typeName string) bool {
+	if c.BeanTypes == nil {
+		return false
+	}
+	return slices.Contains(c.BeanTypes(), typeName)
+}
+
+// Condition decides whether a conditional option is part of the application, the message explains the outcome.
+type Condition interface {
+	Matches(ctx *Context) (bool, string)
+}
+
+type propertyCondition struct {
+	key            string
+	havingValue    string
+	matchIfMissing bool
+}
+
+// OnProperty matches when the property key is set and, if havingValue is not empty, equal to it (ignoring case).
+// A property which is not set matches when matchIfMissing is true, e.g.
+//
+//	condition.OnProperty("management.enabled", "true", true)
+func OnProperty(key string, havingValue string, matchIfMissing bool) Condition {
+	return &propertyCondition{key: key, havingValue: havingValue, matchIfMissing: matchIfMissing}
+}
+
+func (c *propertyCondition) Matches(ctx *Context) (bool, string) {
+	if !ctx.Environment.IsSet(c.key) {
+		if c.matchIfMissing {
+			return true, fmt.Sprintf("property %v is not set and matchIfMissing is true", c.key)
+		}
+		return false, fmt.Sprintf("property %v is not set", c.key)
+	}
+	value := ctx.Environment.GetString(c.key)
+	if c.havingValue == "" {
+		if strings.EqualFold(value, "false") {
+			return false, fmt.Sprintf("property %v is 'false'", c.key)
+		}
+		return true, fmt.Sprintf("property %v is set", c.key)
+	}
+	if strings.EqualFold(value, c.havingValue) {
+		return true, fmt.Sprintf("property %v is '%v'", c.key, value)
+	}
+	return false, fmt.Sprintf("property %v is '%v', expected '%v'", c.key, value, c.havingValue)
+}
+
+type profileCondition struct {
+	profiles []string
+}
+
+// OnProfile matches when any of the profiles is active.
+func OnProfile(profiles ...string) Condition {
+	return &profileCondition{profiles: profiles}
+}
+
+func (c *profileCondition) Matches(ctx *Context) (bool, string) {
+	for _, profile := range c.profiles {
+		if ctx.Profiles.IsActive(profile) {
+			return true, fmt.Sprintf("profile %v is active", profile)
+		}
+	}
+	return false, fmt.Sprintf("none of the profiles %v is active", strings.Join(c.profiles, ", "))
+}
+
+type beanCondition struct {
+	typeName string
+	missing  bool
+}
+
+// OnBean matches when a value of type T is provided by the options listed before the conditional one
+// (or by any option which is not conditional), e.g. condition.OnBean[*sql.DB]().
+func OnBean[T any]() Condition {
+	return &beanCondition{typeName: reflect.TypeFor[T]().String()}
+}
+
+// OnMissingBean matches when no value of type T is provided, it is the opposite of OnBean, e.g.
+// condition.OnMissingBean[*http.Server]() backs off when the application provides its own server.
+func OnMissingBean[T any]() Condition {
+	return &beanCondition{typeName: reflect.TypeFor[T]().String(), missing: true}
+}
+
+func (c *beanCondition) Matches(ctx *Context) (bool, string) {
+	found := ctx.HasBean(c.typeName)
+	switch {
+	case found && c.missing:
+		return false, fmt.Sprintf("found a %v", c.typeName)
+	case found:
+		return true, fmt.Sprintf("found a %v", c.typeName)
+	case c.missing:
+		return true, fmt.Sprintf("did not find any %v", c.typeName)
+	default:
+		return false, fmt.Sprintf("did not find any %v", c.typeName)
+	}
+}
+
+// Option is an fx.Option which goboot only adds to the application when all its conditions match.
+// Only the options given to goboot.Run are evaluated, an Option nested in fx.Options or fx.Module is always added.
+type Option struct {
+	fx.Option
+	Name       string
+	Conditions []Condition
+}
+
+// Conditional returns option guarded by the conditions, e.g.
+//
+//	var ManagementModule = condition.Conditional("management", fx.Module("management", ...),
+//		condition.OnProperty("management.enabled", "true", true))
+func Conditional(name string, option fx.Option, conditions ...Condition) *Option {
+	return &Option{Option: option, Name: name, Conditions: conditions}
+}
+
+// Evaluate checks the conditions in order and stops at the first one which does not match.
+// It returns whether the option must be added and the messages of the evaluated conditions.
+func (o *Option) Evaluate(ctx *Context) (bool, []string) {
+	messages := []string{}
+	for _, condition := range o.Conditions {
+		match, message := condition.Matches(ctx)
+		messages = append(messages, message)
+		if !match {
+			return false, messages
+		}
+	}
+	return true, messages
+}
@@ -0,0 +1,100 @@
+package condition
+
+import (
+	"database/sql"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+func TestOnProperty(t *testing.T) {
+	v := viper.New()
+	v.Set("management.enabled", "false")
+	v.Set("server.mode", "Debug")
+	ctx := &Context{Environment: v}
+	tests := []struct {
+		condition Condition
+		match     bool
+	}{
+		{OnProperty("management.enabled", "true", true), false},
+		{OnProperty("management.enabled", "", false), false},
+		{OnProperty("server.mode", "debug", false), true},
+		{OnProperty("server.mode", "", false), true},
+		{OnProperty("datasource.enabled", "true", true), true},
+		{OnProperty("datasource.enabled", "true", false), false},
+	}
+	for _, test := range tests {
+		match, message := test.condition.Matches(ctx)
+		if match != test.match {
+			t.Errorf("%+v matched %v (%v), expected %v", test.condition, match, message, test.match)
+		}
+	}
+}
+
+func TestOnProfile(t *testing.T) {
+	ctx := &Context{Environment: viper.New(), Profiles: environment.Profiles{"dev", "local"}}
+	if match, _ := OnProfile("prod", "local").Matches(ctx); !match {
+		t.Errorf("local is active")
+	}
+	if match, _ := OnProfile("prod").Matches(ctx); match {
+		t.Errorf("prod is not active")
+	}
+}
+
+func TestProvidedTypes(t *testing.T) {
+	invoked := false
+	types := ProvidedTypes(
+		fx.Provide(func() (*sql.DB, error) { return nil, nil }),
+		fx.Module("private", fx.Provide(fx.Private, func() *http.Server { return nil })),
+		fx.Provide(fx.Annotate(func() int { return 8080 }, fx.ResultTags(`name:"server.port"`))),
+		fx.Supply("value"),
+		fx.Invoke(func(*sql.DB) { invoked = true }),
+	)
+	if invoked {
+		t.Errorf("invokes must not run")
+	}
+	if !slices.Contains(types, "*sql.DB") || !slices.Contains(types, `int[name = "server.port"]`) || !slices.Contains(types, "string") {
+		t.Errorf("unexpected provided types %v", types)
+	}
+	if slices.Contains(types, "*http.Server") {
+		t.Errorf("private types must not be listed %v", types)
+	}
+}
+
+func TestOnBean(t *testing.T) {
+	options := []fx.Option{fx.Provide(func() *sql.DB { return nil })}
+	ctx := &Context{Environment: viper.New(), BeanTypes: func() []string { return ProvidedTypes(options...) }}
+	if match, message := OnBean[*sql.DB]().Matches(ctx); !match {
+		t.Errorf("*sql.DB is provided: %v", message)
+	}
+	if match, _ := OnMissingBean[*sql.DB]().Matches(ctx); match {
+		t.Errorf("*sql.DB is provided")
+	}
+	if match, _ := OnMissingBean[*http.Server]().Matches(ctx); !match {
+		t.Errorf("*http.Server is not provided")
+	}
+}
+
+func TestEvaluateStopsAtFirstMismatch(t *testing.T) {
+	v := viper.New()
+	v.Set("management.enabled", false)
+	evaluated := false
+	option := Conditional("management", fx.Options(),
+		OnProperty("management.enabled", "true", true),
+		conditionFunc(func(*Context) (bool, string) { evaluated = true; return true, "" }),
+	)
+	match, messages := option.Evaluate(&Context{Environment: v})
+	if match || len(messages) != 1 || evaluated {
+		t.Errorf("unexpected evaluation %v %v", match, messages)
+	}
+}
+
+type conditionFunc func(*Context) (bool, string)
+
+func (f conditionFunc) Matches(ctx *Context) (bool, string) {
+	return f(ctx)
+}
@@ -0,0 +1,44 @@
+package condition
+
+import (
+	"errors"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+// errProvidedTypesCollected ends the build of the application once the provided types are known.
+var errProvidedTypesCollected = errors.New("provided types collected")
+
+// collectProvidedTypes is the first module of the application: the invokes of a module run before the ones of the
+// modules registered after it and of the parent, and fx runs no more invokes once one returns an error.
+var collectProvidedTypes = fx.Module("goboot-provided-types",
+	fx.Invoke(func() error { return errProvidedTypesCollected }),
+)
+
+type providedTypesLogger struct {
+	types []string
+}
+
+func (l *providedTypesLogger) LogEvent(event fxevent.Event) {
+	switch e := event.(type) {
+	case *fxevent.Provided:
+		// private constructors are only visible inside their module
+		if e.Err == nil && !e.Private {
+			l.types = append(l.types, e.OutputTypeNames...)
+		}
+	case *fxevent.Supplied:
+		if e.Err == nil {
+			l.types = append(l.types, e.TypeName)
+		}
+	}
+}
+
+// ProvidedTypes returns the names of the types provided or supplied by the options, as the Provided events of fx
+// print them. The application of the options is built but never started, and none of its constructors or invokes run.
+func ProvidedTypes(options ...fx.Option) []string {
+	logger := &providedTypesLogger{}
+	options = append([]fx.Option{collectProvidedTypes}, options...)
+	fx.New(append(options, fx.WithLogger(func() fxevent.Logger { return logger }))...)
+	return logger.types
+}
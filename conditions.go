@@ -0,0 +1,145 @@
+package goboot
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/condition"
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+const goboot_autoconfigure_exclude_property_name = "goboot.autoconfigure.exclude"
+
+// listed_conditional_order is the order of the listed conditional options which are not auto-configurations.
+const listed_conditional_order = 0
+
+type conditionOutcome struct {
+	name     string
+	match    bool
+	messages []string
+}
+
+// conditionsReport tells which conditional options and auto-configurations were applied or skipped and why.
+type conditionsReport struct {
+	positiveMatches []conditionOutcome
+	negativeMatches []conditionOutcome
+	exclusions      []string
+}
+
+func (r *conditionsReport) add(outcome conditionOutcome) {
+	if outcome.match {
+		r.positiveMatches = append(r.positiveMatches, outcome)
+	} else {
+		r.negativeMatches = append(r.negativeMatches, outcome)
+	}
+}
+
+func (r *conditionsReport) String() string {
+	report := strings.Builder{}
+	report.WriteString("\n\n============================\nCONDITIONS EVALUATION REPORT\n============================\n")
+	writeSection := func(title string, outcomes []conditionOutcome, verb string) {
+		report.WriteString(fmt.Sprintf("\n%v:\n%v\n", title, strings.Repeat("-", len(title)+1)))
+		if len(outcomes) == 0 {
+			report.WriteString("\n    None\n")
+		}
+		for _, outcome := range outcomes {
+			report.WriteString(fmt.Sprintf("\n    %v %v:\n", outcome.name, verb))
+			for _, message := range outcome.messages {
+				report.WriteString(fmt.Sprintf("        - %v\n", message))
+			}
+		}
+	}
+	writeSection("Positive matches", r.positiveMatches, "matched")
+	writeSection("Negative matches", r.negativeMatches, "did not match")
+	report.WriteString("\nExclusions:\n-----------\n\n")
+	if len(r.exclusions) == 0 {
+		report.WriteString("    None\n")
+	}
+	for _, exclusion := range r.exclusions {
+		report.WriteString(fmt.Sprintf("    %v\n", exclusion))
+	}
+	return report.String()
+}
+
+// evaluateConditions returns the options to add to the application: the listed ones, without the conditional ones
+// whose conditions do not match, and then the matching auto-configurations, an auto-configuration being skipped when
+// it is excluded or when a conditional option with its name is listed in options. Conditional options and auto-configurations are evaluated
+// in a single pass by ascending order: a listed option has the order of the auto-configuration with its name (e.g. a
+// listed supportfx.GormModule is evaluated after the auto-configured datasource), the other listed options come first
+// in the order they are listed. Bean conditions see the base options, every plain option and the options accepted
+// before them.
+func (app *GobootApplication) evaluateConditions(v *viper.Viper, profiles environment.Profiles, baseOptions []fx.Option, options []fx.Option, autoConfigurations []*autoconfigure.AutoConfiguration) ([]fx.Option, *conditionsReport) {
+	plainOptions := slices.Clone(baseOptions)
+	conditionals := []*autoconfigure.AutoConfiguration{}
+	listed := []string{}
+	for _, option := range options {
+		conditional, ok := option.(*condition.Option)
+		if !ok {
+			plainOptions = append(plainOptions, option)
+			continue
+		}
+		listed = append(listed, conditional.Name)
+		order := listed_conditional_order
+		for _, autoConfiguration := range autoConfigurations {
+			if autoConfiguration.Name == conditional.Name {
+				order = autoConfiguration.Order
+			}
+		}
+		conditionals = append(conditionals, &autoconfigure.AutoConfiguration{Option: conditional, Order: order})
+	}
+	report := &conditionsReport{}
+	exclude := cast.ToStringSlice(splitListProperty(v.Get(goboot_autoconfigure_exclude_property_name)))
+	for _, autoConfiguration := range autoConfigurations {
+		if slices.Contains(listed, autoConfiguration.Name) {
+			continue
+		}
+		if slices.Contains(exclude, autoConfiguration.Name) {
+			report.exclusions = append(report.exclusions, autoConfiguration.Name)
+			continue
+		}
+		conditionals = append(conditionals, autoConfiguration)
+	}
+	slices.SortStableFunc(conditionals, func(a, b *autoconfigure.AutoConfiguration) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+	accepted := []fx.Option{}
+	var beanTypes []string
+	ctx := &condition.Context{
+		Environment: v,
+		Profiles:    profiles,
+		BeanTypes: func() []string {
+			if beanTypes == nil {
+				beanTypes = condition.ProvidedTypes(append(slices.Clone(plainOptions), accepted...)...)
+			}
+			return beanTypes
+		},
+	}
+	matched := make(map[*condition.Option]bool)
+	for _, conditional := range conditionals {
+		match, messages := conditional.Evaluate(ctx)
+		report.add(conditionOutcome{name: conditional.Name, match: match, messages: messages})
+		if match {
+			accepted = append(accepted, conditional.Option.Option)
+			beanTypes = nil
+			matched[conditional.Option] = true
+		}
+	}
+	result := []fx.Option{}
+	for _, option := range options {
+		if conditional, ok := option.(*condition.Option); !ok || matched[conditional] {
+			result = append(result, option)
+		}
+	}
+	for _, autoConfiguration := range autoConfigurations {
+		if matched[autoConfiguration.Option] {
+			result = append(result, autoConfiguration.Option)
+		}
+	}
+	return result, report
+}
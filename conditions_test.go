@@ -0,0 +1,79 @@
+package goboot
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/condition"
+	"go.uber.org/fx"
+)
+
+func TestEvaluateConditions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, "application.yaml", "management:\n  enabled: false\n")
+
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+	management := condition.Conditional("management", fx.Options(), condition.OnProperty("management.enabled", "true", true))
+	datasource := condition.Conditional("datasource", fx.Provide(func() *sql.DB { return nil }), condition.OnProperty("datasource.enabled", "true", true))
+	gorm := condition.Conditional("gorm", fx.Options(), condition.OnBean[*sql.DB]())
+	web := condition.Conditional("web", fx.Options(), condition.OnMissingBean[*http.Server]())
+	userServer := fx.Provide(func() *http.Server { return nil })
+
+	options, _ := app.evaluateConditions(v, profiles, nil, []fx.Option{management, datasource, gorm, web, userServer}, nil)
+
+	// management is disabled and web backs off because of the user provided server
+	if len(options) != 3 || options[0] != datasource || options[1] != gorm {
+		t.Fatalf("Unexpected options %v", options)
+	}
+}
+
+func TestAutoConfigurations(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, "application.yaml", "goboot:\n  autoconfigure:\n    exclude: management\n")
+
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+	datasource := condition.Conditional("datasource", fx.Provide(func() *sql.DB { return nil }))
+	autoConfigurations := []*autoconfigure.AutoConfiguration{
+		{Option: datasource, Order: autoconfigure.DATASOURCE_ORDER},
+		{Option: condition.Conditional("gorm", fx.Options(), condition.OnBean[*sql.DB]()), Order: autoconfigure.GORM_ORDER},
+		{Option: condition.Conditional("tx", fx.Options(), condition.OnBean[*http.Client]()), Order: autoconfigure.TX_ORDER},
+		{Option: condition.Conditional("management", fx.Options()), Order: autoconfigure.MANAGEMENT_ORDER},
+	}
+	listedDatasource := condition.Conditional("datasource", fx.Provide(func() *sql.DB { return nil }))
+
+	options, report := app.evaluateConditions(v, profiles, nil, []fx.Option{listedDatasource}, autoConfigurations)
+
+	// the listed datasource replaces the auto-configured one
+	if len(options) != 2 || options[0] != listedDatasource || options[1] != autoConfigurations[1].Option {
+		t.Fatalf("Unexpected options %v", options)
+	}
+	text := report.String()
+	for _, expected := range []string{"gorm matched:\n        - found a *sql.DB", "tx did not match:\n        - did not find any *http.Client", "Exclusions:\n-----------\n\n    management\n"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Report does not contain %q:%v", expected, text)
+		}
+	}
+}
+
+func TestListedConditionalAfterAutoConfiguration(t *testing.T) {
+	t.Chdir(t.TempDir())
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+	autoConfigurations := []*autoconfigure.AutoConfiguration{
+		{Option: condition.Conditional("datasource", fx.Provide(func() *sql.DB { return nil })), Order: autoconfigure.DATASOURCE_ORDER},
+		{Option: condition.Conditional("gorm", fx.Options(), condition.OnBean[*sql.DB]()), Order: autoconfigure.GORM_ORDER},
+	}
+	listedGorm := condition.Conditional("gorm", fx.Options(), condition.OnBean[*sql.DB]())
+
+	options, report := app.evaluateConditions(v, profiles, nil, []fx.Option{listedGorm}, autoConfigurations)
+
+	// the listed gorm has the order of the gorm auto-configuration, so it sees the auto-configured datasource
+	if len(options) != 2 || options[0] != listedGorm || options[1] != autoConfigurations[0].Option {
+		t.Fatalf("Unexpected options %v%v", options, report)
+	}
+}
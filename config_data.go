@@ -0,0 +1,256 @@
+package goboot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+const config_location_property_name = "config.location"
+const config_location_env_name = "GOBOOT_CONFIG_LOCATION"
+const config_import_property_name = "config.import"
+const config_default_location = "./"
+const config_optional_prefix = "optional:"
+const config_file_prefix = "file:"
+const config_configtree_prefix = "configtree:"
+const config_dotenv_location_property_name = "config.dotenv.location"
+const config_dotenv_default_location = ".env"
+const application_config_base_name = "application"
+const application_config_extension = ".yaml"
+
+// configDataNotFoundError is returned when a config location or a config import, not marked as optional:, does not exist.
+type configDataNotFoundError struct {
+	Location string
+	Origin   string
+}
+
+func (e *configDataNotFoundError) Error() string {
+	return fmt.Sprintf("config data location '%v' (from %v) does not exist", e.Location, e.Origin)
+}
+
+type configLocation struct {
+	path      string
+	directory bool
+	optional  bool
+}
+
+// configLocations returns the locations listed in config.location (e.g. --config.location=/etc/app/,./override.yaml),
+// or in the GOBOOT_CONFIG_LOCATION environment variable, or the working directory when there is none.
+func (app *GobootApplication) configLocations(v *viper.Viper) ([]configLocation, error) {
+	value := config_default_location
+	if v.IsSet(config_location_property_name) {
+		value = v.GetString(config_location_property_name)
+	} else if env, found := os.LookupEnv(config_location_env_name); found {
+		value = env
+	}
+	locations := []configLocation{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		location := configLocation{}
+		location.path, location.optional = strings.CutPrefix(item, config_optional_prefix)
+		location.path = strings.TrimPrefix(location.path, config_file_prefix)
+		info, err := os.Stat(location.path)
+		if err != nil {
+			if !location.optional {
+				return nil, &configDataNotFoundError{Location: item, Origin: config_location_property_name}
+			}
+			location.directory = strings.HasSuffix(location.path, "/")
+		} else {
+			location.directory = info.IsDir()
+		}
+		locations = append(locations, location)
+	}
+	return locations, nil
+}
+
+// configFileNames returns the configuration files in the order they are merged: the application.yaml
+// (or the file itself) of every location and then, for each active profile, the application-{profile}.yaml of every location.
+func (app *GobootApplication) configFileNames(locations []configLocation, profiles []string) []string {
+	fileNames := []string{}
+	for _, profile := range append([]string{""}, profiles...) {
+		for _, location := range locations {
+			fileNames = append(fileNames, location.fileName(profile))
+		}
+	}
+	return fileNames
+}
+
+func (l configLocation) fileName(profile string) string {
+	if l.directory {
+		if profile == "" {
+			return filepath.Join(l.path, application_config_base_name+application_config_extension)
+		}
+		return filepath.Join(l.path, fmt.Sprintf("%v-%v%v", application_config_base_name, profile, application_config_extension))
+	}
+	if profile == "" {
+		return l.path
+	}
+	extension := filepath.Ext(l.path)
+	return fmt.Sprintf("%v-%v%v", strings.TrimSuffix(l.path, extension), profile, extension)
+}
+
+// mergeConfigFile merges the file into the environment, followed by the files and config trees it lists in config.import
+// (relative paths are resolved from the directory of the importing file). The file is the origin of its properties.
+func (app *GobootApplication) mergeConfigFile(v *viper.Viper, origins *environment.PropertyOrigins, fileName string, imported []string) error {
+	_, errCfgFile := os.Stat(fileName)
+	if errCfgFile != nil {
+		slog.Debug(fmt.Sprintf("%v was not found", fileName))
+		return nil
+	}
+	fileV := viper.New()
+	fileV.SetConfigFile(fileName)
+	errRead := fileV.ReadInConfig()
+	if errRead != nil {
+		slog.Warn(fmt.Sprintf("%v was not successfully read, %s", fileName, errRead))
+		return nil
+	}
+	if errMerge := v.MergeConfigMap(fileV.AllSettings()); errMerge != nil {
+		slog.Warn(fmt.Sprintf("%v was not successfully merged, %s", fileName, errMerge))
+		return nil
+	}
+	origins.RecordAll(fileV.AllSettings(), fmt.Sprintf(environment.ORIGIN_CONFIG_FILE, fileName))
+	for _, item := range cast.ToStringSlice(splitListProperty(fileV.Get(config_import_property_name))) {
+		location, optional := strings.CutPrefix(item, config_optional_prefix)
+		location, isTree := strings.CutPrefix(location, config_configtree_prefix)
+		location, isFile := strings.CutPrefix(location, config_file_prefix)
+		if !isFile && !isTree && strings.Contains(location, ":") {
+			return fmt.Errorf("config import '%v' from %v is not supported, only file: and configtree: imports are", item, fileName)
+		}
+		if !filepath.IsAbs(location) {
+			location = filepath.Join(filepath.Dir(fileName), location)
+		}
+		if slices.Contains(imported, location) {
+			continue
+		}
+		if _, err := os.Stat(location); err != nil {
+			if optional {
+				slog.Debug(fmt.Sprintf("Optional config import %v was not found", location))
+				continue
+			}
+			return &configDataNotFoundError{Location: item, Origin: fileName}
+		}
+		if isTree {
+			if err := app.mergeConfigTree(v, origins, location); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := app.mergeConfigFile(v, origins, location, append(imported, fileName, location)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeConfigTree merges the properties of the config tree into the environment, every file is the origin of its property.
+func (app *GobootApplication) mergeConfigTree(v *viper.Viper, origins *environment.PropertyOrigins, dir string) error {
+	properties, err := environment.ReadConfigTree(dir)
+	if err != nil {
+		return fmt.Errorf("config tree %v was not successfully read, %w", dir, err)
+	}
+	settings := make(map[string]any)
+	for _, property := range properties {
+		environment.SetProperty(settings, property.Key, property.Value)
+	}
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("config tree %v was not successfully merged, %w", dir, err)
+	}
+	for _, property := range properties {
+		origins.Record(property.Key, fmt.Sprintf(environment.ORIGIN_CONFIG_TREE, property.FileName))
+	}
+	return nil
+}
+
+// mergeModuleDefaults merges the defaults of the modules, in their order, after the embedded default.yaml.
+// A warning is logged when a module sets a property already set by default.yaml or by a previous module.
+func (app *GobootApplication) mergeModuleDefaults(v *viper.Viper, origins *environment.PropertyOrigins, defaults []*autoconfigure.ModuleDefaults) {
+	owners := make(map[string]string)
+	for key, value := range environment.FlattenSettings(v.AllSettings()) {
+		if value != nil {
+			owners[key] = environment.ORIGIN_DEFAULTS
+		}
+	}
+	for _, moduleDefaults := range defaults {
+		origin := fmt.Sprintf(environment.ORIGIN_MODULE_DEFAULTS, moduleDefaults.Name)
+		moduleV := viper.New()
+		moduleV.SetConfigType("yaml")
+		if err := moduleV.ReadConfig(bytes.NewReader(moduleDefaults.Data)); err != nil {
+			slog.Warn(fmt.Sprintf("Defaults of module %v were not successfully read, %s", moduleDefaults.Name, err))
+			continue
+		}
+		settings := moduleV.AllSettings()
+		flat := environment.FlattenSettings(settings)
+		for _, key := range slices.Sorted(maps.Keys(flat)) {
+			value := flat[key]
+			if owner, found := owners[key]; found {
+				slog.Warn(fmt.Sprintf("Default property %v of module %v overrides the one of %v", key, moduleDefaults.Name, owner))
+			}
+			if value != nil {
+				owners[key] = origin
+			}
+		}
+		if err := v.MergeConfigMap(settings); err != nil {
+			slog.Warn(fmt.Sprintf("Defaults of module %v were not successfully merged, %s", moduleDefaults.Name, err))
+			continue
+		}
+		origins.RecordAll(settings, origin)
+	}
+}
+
+// mergePropertySource merges the properties of the source into the environment, the source is their origin.
+func (app *GobootApplication) mergePropertySource(v *viper.Viper, origins *environment.PropertyOrigins, source environment.PropertySource) error {
+	properties, err := source.Properties()
+	if err != nil {
+		return fmt.Errorf("property source %v was not successfully read, %w", source.Name(), err)
+	}
+	settings := environment.PropertySettings(properties)
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("property source %v was not successfully merged, %w", source.Name(), err)
+	}
+	origins.RecordAll(settings, fmt.Sprintf(environment.ORIGIN_PROPERTY_SOURCE, source.Name()))
+	return nil
+}
+
+// readDotenv returns the variables of the config.dotenv.location file, nothing when it does not exist.
+func (app *GobootApplication) readDotenv(v *viper.Viper) (string, []string, error) {
+	fileName := v.GetString(config_dotenv_location_property_name)
+	if fileName == "" {
+		return fileName, nil, nil
+	}
+	environ, err := environment.ReadDotenvFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		slog.Debug(fmt.Sprintf("%v was not found", fileName))
+		return fileName, nil, nil
+	}
+	if err != nil {
+		return fileName, nil, fmt.Errorf("dotenv file %v was not successfully read, %w", fileName, err)
+	}
+	return fileName, environ, nil
+}
+
+func splitListProperty(value any) any {
+	if s, ok := value.(string); ok {
+		items := []string{}
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return value
+}
@@ -0,0 +1,210 @@
+package goboot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sjexpos/goboot/environment"
+	"github.com/sjexpos/goboot/log"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+const config_watch_enabled_property_name = "config.watch.enabled"
+const config_watch_delay_property_name = "config.watch.delay"
+const config_watch_default_delay = 500 * time.Millisecond
+
+// configWatcher reloads the environment when one of the configuration files is created, updated or removed,
+// and notifies the ConfigChangedListener(s) about the properties which have changed.
+type configWatcher struct {
+	app       *GobootApplication
+	live      *environment.LiveEnvironment
+	profiles  environment.Profiles
+	listeners []environment.ConfigChangedListener
+	delay     time.Duration
+	files     []string
+	watcher   *fsnotify.Watcher
+	timer     *time.Timer
+	stopped   bool
+	mutex     sync.Mutex
+}
+
+type configWatcherParams struct {
+	fx.In
+
+	Lifecycle   fx.Lifecycle
+	Environment *environment.LiveEnvironment
+	Profiles    environment.Profiles
+	Listeners   []environment.ConfigChangedListener `group:"config-changed-listeners"`
+}
+
+func (app *GobootApplication) createConfigWatcherModule(v *viper.Viper) fx.Option {
+	if !v.GetBool(config_watch_enabled_property_name) {
+		return fx.Options()
+	}
+	return fx.Invoke(func(params configWatcherParams) error {
+		v := params.Environment.Get()
+		locations, err := app.configLocations(v)
+		if err != nil {
+			return err
+		}
+		w := &configWatcher{
+			app:       app,
+			live:      params.Environment,
+			profiles:  params.Profiles,
+			listeners: params.Listeners,
+			delay:     config_watch_default_delay,
+			files:     app.configFileNames(locations, params.Profiles),
+		}
+		if v.IsSet(config_watch_delay_property_name) {
+			w.delay = v.GetDuration(config_watch_delay_property_name)
+		}
+		params.Lifecycle.Append(fx.Hook{
+			OnStart: w.start,
+			OnStop:  w.stop,
+		})
+		return nil
+	})
+}
+
+func (w *configWatcher) start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// directories are watched instead of files, so files replaced by editors or created later are seen
+	directories := []string{}
+	for _, file := range w.files {
+		directory := filepath.Dir(file)
+		if !slices.Contains(directories, directory) {
+			directories = append(directories, directory)
+			if err := watcher.Add(directory); err != nil {
+				watcher.Close()
+				return err
+			}
+		}
+	}
+	w.watcher = watcher
+	go w.watch()
+	slog.Info(fmt.Sprintf("Watching configuration files %v", w.files))
+	return nil
+}
+
+func (w *configWatcher) stop(ctx context.Context) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.stopped = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	return w.watcher.Close()
+}
+
+func (w *configWatcher) watch() {
+	log.MDC.Set(log.GO_ROUTINE_NAME_FIELD_NAME, "config-watcher")
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if w.isConfigFile(event.Name) {
+				w.scheduleReload()
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("Configuration files watcher failed", slog.Any("error", err))
+		}
+	}
+}
+
+func (w *configWatcher) isConfigFile(name string) bool {
+	for _, file := range w.files {
+		if filepath.Clean(file) == filepath.Clean(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleReload waits until the files stop changing, editors usually write a file in several steps.
+func (w *configWatcher) scheduleReload() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.stopped {
+		return
+	}
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.delay, w.reload)
+}
+
+// reload prepares a new environment and, when properties have changed, publishes it in the live environment.
+// Timers which fire after the watcher was stopped do nothing.
+func (w *configWatcher) reload() {
+	log.MDC.Set(log.GO_ROUTINE_NAME_FIELD_NAME, "config-watcher")
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.stopped {
+		return
+	}
+	previousOrigins := environment.NewPropertyOrigins()
+	previousOrigins.Replace(w.app.origins)
+	current, profiles, err := w.app.prepareEnvironment()
+	if err != nil {
+		slog.Error("Configuration could not be reloaded, previous values are kept", slog.Any("error", err))
+		return
+	}
+	if !slices.Equal(profiles, w.profiles) {
+		w.app.origins.Replace(previousOrigins)
+		slog.Warn(fmt.Sprintf("Active profiles can not be changed without a restart, configuration was not reloaded (active: %v)", w.profiles))
+		return
+	}
+	keys := changedKeys(w.live.Get(), current)
+	if len(keys) == 0 {
+		slog.Debug("Configuration files were reloaded, no property has changed")
+		return
+	}
+	// the new environment is fully built before it is published, readers see either the previous one or this one
+	w.live.Set(current)
+	slog.Info(fmt.Sprintf("Configuration was reloaded, changed properties: %v", keys))
+	event := &environment.ConfigChangedEvent{Keys: keys, Environment: current}
+	w.app.onConfigChanged(event)
+	for _, listener := range w.listeners {
+		listener.OnConfigChanged(event)
+	}
+}
+
+func changedKeys(previous *viper.Viper, current *viper.Viper) []string {
+	keys := []string{}
+	for _, key := range previous.AllKeys() {
+		if !reflect.DeepEqual(previous.Get(key), current.Get(key)) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range current.AllKeys() {
+		if !previous.IsSet(key) && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// onConfigChanged applies the changes goboot itself can handle without a restart.
+func (app *GobootApplication) onConfigChanged(event *environment.ConfigChangedEvent) {
+	if event.HasChanged(application_log_property_name) {
+		log.SetRootLoggerLevel(app.logLevel(event.Environment))
+		slog.Info(fmt.Sprintf("Log level was changed to %v", event.Environment.GetString(application_log_property_name)))
+	}
+}
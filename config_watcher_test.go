@@ -0,0 +1,72 @@
+package goboot
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sjexpos/goboot/environment"
+)
+
+type recordingListener struct {
+	events []*environment.ConfigChangedEvent
+}
+
+func (l *recordingListener) OnConfigChanged(event *environment.ConfigChangedEvent) {
+	l.events = append(l.events, event)
+}
+
+type greetingProperties struct {
+	Message string `mapstructure:"message"`
+}
+
+func TestConfigReload(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, "application.yaml", "greeting:\n  message: hello\n  target: world\n")
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+	refreshable, err := environment.NewRefreshable[greetingProperties](v, "greeting")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	listener := &recordingListener{}
+	w := &configWatcher{
+		app:       app,
+		live:      environment.NewLiveEnvironment(v),
+		profiles:  profiles,
+		listeners: []environment.ConfigChangedListener{listener, refreshable},
+	}
+
+	writeConfigFile(t, "application.yaml", "greeting:\n  message: bye\n  extra: true\n")
+	w.reload()
+
+	if len(listener.events) != 1 {
+		t.Fatalf("One event was expected, got %v", len(listener.events))
+	}
+	if keys := listener.events[0].Keys; !slices.Equal(keys, []string{"greeting.extra", "greeting.message", "greeting.target"}) {
+		t.Errorf("Unexpected changed keys: %v", keys)
+	}
+	if message := w.live.Get().GetString("greeting.message"); message != "bye" {
+		t.Errorf("Environment was not updated, greeting.message is %v", message)
+	}
+	if message := v.GetString("greeting.message"); message != "hello" {
+		t.Errorf("The previous environment should not be changed, greeting.message is %v", message)
+	}
+	if message := refreshable.Get().Message; message != "bye" {
+		t.Errorf("Refreshable properties were not updated, message is %v", message)
+	}
+}
+
+func TestConfigReloadAfterStop(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, "application.yaml", "greeting:\n  message: hello\n")
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+	w := &configWatcher{app: app, live: environment.NewLiveEnvironment(v), profiles: profiles, stopped: true}
+
+	writeConfigFile(t, "application.yaml", "greeting:\n  message: bye\n")
+	w.reload()
+
+	if message := w.live.Get().GetString("greeting.message"); message != "hello" {
+		t.Errorf("A stopped watcher should not reload, greeting.message is %v", message)
+	}
+}
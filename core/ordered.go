@@ -1,8 +1,28 @@
 package core
 
+import "sort"
+
 const ORDERED_HIGHEST_PRECEDENCE = 0
 const ORDERED_LOWEST_PRECEDENCE = int(^uint(0) >> 1)
 
 type Ordered interface {
 	GetOrder() int
 }
+
+// SortByOrder returns the items which implement Ordered first, by ascending order, followed by the other
+// items in their original order.
+func SortByOrder[T any](items []T) []T {
+	var ordered []T
+	var unordered []T
+	for _, item := range items {
+		if _, ok := any(item).(Ordered); ok {
+			ordered = append(ordered, item)
+		} else {
+			unordered = append(unordered, item)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return any(ordered[i]).(Ordered).GetOrder() < any(ordered[j]).(Ordered).GetOrder()
+	})
+	return append(ordered, unordered...)
+}
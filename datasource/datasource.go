@@ -7,22 +7,75 @@ import (
 	"time"
 )
 
+// ConnectionError is returned when the database can not be reached while the datasource is created.
+type ConnectionError struct {
+	Host string
+	Port int
+	Err  error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("cannot connect to database at %v:%v: %v", e.Host, e.Port, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
 func NewDatasource(host string, port int, username string, password string, dbname string, poolMaxIdleConnections int, poolMaxOpenConnections int, poolConnectionMaxLifetime time.Duration, poolConnectionMaxIdleTime time.Duration) (*sql.DB, error) {
+	sqlDB, err := OpenDatasource(host, port, username, password, dbname, poolMaxIdleConnections, poolMaxOpenConnections, poolConnectionMaxLifetime, poolConnectionMaxIdleTime)
+	if err != nil {
+		return nil, err
+	}
+	_, sqlErr := sqlDB.Exec("SELECT 1")
+	if sqlErr != nil {
+		sqlDB.Close()
+		return nil, &ConnectionError{Host: host, Port: port, Err: sqlErr}
+	}
+	slog.Info("Database connection was set up")
+	return sqlDB, nil
+}
+
+// OpenDatasource configures the pool like NewDatasource but does not connect to the database, the first query does.
+func OpenDatasource(host string, port int, username string, password string, dbname string, poolMaxIdleConnections int, poolMaxOpenConnections int, poolConnectionMaxLifetime time.Duration, poolConnectionMaxIdleTime time.Duration) (*sql.DB, error) {
 	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s "+
 		"password=%s dbname=%s sslmode=disable",
 		host, port, username, password, dbname)
 	sqlDB, errDB := sql.Open("postgres", psqlInfo)
 	if errDB != nil {
-		return nil, errDB
+		return nil, &ConnectionError{Host: host, Port: port, Err: errDB}
 	}
 	sqlDB.SetMaxIdleConns(poolMaxIdleConnections)
 	sqlDB.SetMaxOpenConns(poolMaxOpenConnections)
 	sqlDB.SetConnMaxLifetime(poolConnectionMaxLifetime)
 	sqlDB.SetConnMaxIdleTime(poolConnectionMaxIdleTime)
-	_, sqlErr := sqlDB.Exec("SELECT 1")
-	if sqlErr != nil {
-		return nil, sqlErr
-	}
-	slog.Info("Database connection was set up")
 	return sqlDB, nil
 }
+
+func NewDatasourceFromProperties(properties *DatasourceProperties) (*sql.DB, error) {
+	return NewDatasource(
+		properties.Host,
+		properties.Port,
+		properties.Username,
+		properties.Password,
+		properties.SchemaName,
+		properties.Pool.MaxIdle.Connections,
+		properties.Pool.MaxOpen.Connections,
+		properties.Pool.MaxLifetime.Connection,
+		properties.Pool.MaxIdleTime.Connection,
+	)
+}
+
+func OpenDatasourceFromProperties(properties *DatasourceProperties) (*sql.DB, error) {
+	return OpenDatasource(
+		properties.Host,
+		properties.Port,
+		properties.Username,
+		properties.Password,
+		properties.SchemaName,
+		properties.Pool.MaxIdle.Connections,
+		properties.Pool.MaxOpen.Connections,
+		properties.Pool.MaxLifetime.Connection,
+		properties.Pool.MaxIdleTime.Connection,
+	)
+}
@@ -0,0 +1,29 @@
+package datasource
+
+import "time"
+
+const DATASOURCE_PROPERTIES_PREFIX = "datasource"
+
+type DatasourceProperties struct {
+	Host       string                   `mapstructure:"host" validate:"required"`
+	Port       int                      `mapstructure:"port" default:"5432" validate:"min=1,max=65535"`
+	Username   string                   `mapstructure:"username"`
+	Password   string                   `mapstructure:"password"`
+	SchemaName string                   `mapstructure:"schema_name" validate:"required"`
+	Pool       DatasourcePoolProperties `mapstructure:"pool"`
+}
+
+type DatasourcePoolProperties struct {
+	MaxIdle     PoolConnectionsProperties `mapstructure:"max_idle"`
+	MaxOpen     PoolConnectionsProperties `mapstructure:"max_open"`
+	MaxLifetime PoolConnectionProperties  `mapstructure:"max_lifetime"`
+	MaxIdleTime PoolConnectionProperties  `mapstructure:"max_idle_time"`
+}
+
+type PoolConnectionsProperties struct {
+	Connections int `mapstructure:"connections" validate:"min=0"`
+}
+
+type PoolConnectionProperties struct {
+	Connection time.Duration `mapstructure:"connection" validate:"min=0"`
+}
@@ -0,0 +1,97 @@
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PREFIX marks the encrypted property values, e.g. password: "{cipher}q0LyXMc...".
+const PREFIX = "{cipher}"
+
+// KEY_SIZE is the size of the keys made by GenerateKey, AES-256.
+const KEY_SIZE = 32
+
+var errMalformed = errors.New("the value is not a valid encrypted value")
+
+// TextEncryptor encrypts and decrypts text with AES-GCM, the encrypted values are the base64 of the nonce
+// followed by the sealed text.
+type TextEncryptor struct {
+	aead cipher.AEAD
+}
+
+// NewTextEncryptor returns an encryptor with key, an AES key of 16, 24 or 32 bytes.
+func NewTextEncryptor(key []byte) (*TextEncryptor, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &TextEncryptor{aead: aead}, nil
+}
+
+// Encrypt returns the encrypted value of text, with the {cipher} prefix.
+func (e *TextEncryptor) Encrypt(text string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := e.aead.Seal(nonce, nonce, []byte(text), nil)
+	return PREFIX + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the text of an encrypted value, the {cipher} prefix is optional.
+func (e *TextEncryptor) Decrypt(value string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(strings.TrimSpace(value), PREFIX))
+	if err != nil || len(sealed) < e.aead.NonceSize() {
+		return "", errMalformed
+	}
+	nonce, sealed := sealed[:e.aead.NonceSize()], sealed[e.aead.NonceSize():]
+	text, err := e.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		// the error of GCM does not tell more, the key is wrong or the value was altered
+		return "", fmt.Errorf("the value can not be decrypted with this key")
+	}
+	return string(text), nil
+}
+
+// IsEncrypted reports whether value is a string with the {cipher} prefix.
+func IsEncrypted(value any) bool {
+	text, isString := value.(string)
+	return isString && strings.HasPrefix(strings.TrimSpace(text), PREFIX)
+}
+
+// GenerateKey returns a new random key, base64 encoded.
+func GenerateKey() (string, error) {
+	key := make([]byte, KEY_SIZE)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// ParseKey decodes a base64 encoded key.
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("the key is not base64 encoded: %w", err)
+	}
+	return key, nil
+}
+
+// ReadKeyFile reads a base64 encoded key from a file.
+func ReadKeyFile(fileName string) ([]byte, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKey(string(data))
+}
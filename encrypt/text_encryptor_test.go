@@ -0,0 +1,65 @@
+package encrypt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newEncryptor(t *testing.T) *TextEncryptor {
+	encoded, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ParseKey(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptor, err := NewTextEncryptor(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encryptor
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	encryptor := newEncryptor(t)
+	encrypted, err := encryptor.Encrypt("s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(encrypted) || strings.Contains(encrypted, "s3cr3t") {
+		t.Errorf("Unexpected encrypted value %v", encrypted)
+	}
+	again, _ := encryptor.Encrypt("s3cr3t")
+	if again == encrypted {
+		t.Errorf("Each encryption should use a new nonce")
+	}
+	if text, err := encryptor.Decrypt(encrypted); err != nil || text != "s3cr3t" {
+		t.Errorf("Expected s3cr3t, got %v (%v)", text, err)
+	}
+	if text, err := encryptor.Decrypt(strings.TrimPrefix(encrypted, PREFIX)); err != nil || text != "s3cr3t" {
+		t.Errorf("The prefix should be optional, got %v (%v)", text, err)
+	}
+}
+
+func TestDecryptWithAnotherKey(t *testing.T) {
+	encrypted, _ := newEncryptor(t).Encrypt("s3cr3t")
+	if _, err := newEncryptor(t).Decrypt(encrypted); err == nil {
+		t.Errorf("A value encrypted with another key should not be decrypted")
+	}
+	if _, err := newEncryptor(t).Decrypt("{cipher}not base64!"); err == nil {
+		t.Errorf("A malformed value should not be decrypted")
+	}
+}
+
+func TestReadKeyFile(t *testing.T) {
+	encoded, _ := GenerateKey()
+	fileName := filepath.Join(t.TempDir(), "goboot.key")
+	os.WriteFile(fileName, []byte(encoded+"\n"), 0o600)
+	key, err := ReadKeyFile(fileName)
+	if err != nil || len(key) != KEY_SIZE {
+		t.Errorf("Unexpected key %v (%v)", key, err)
+	}
+}
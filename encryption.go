@@ -0,0 +1,37 @@
+package goboot
+
+import (
+	"github.com/sjexpos/goboot/encrypt"
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/viper"
+)
+
+const goboot_encrypt_key_property_name = "goboot.encrypt.key"
+const goboot_encrypt_key_file_property_name = "goboot.encrypt.key-file"
+
+// decryptProperties decrypts the {cipher} values of the settings with the base64 key of goboot.encrypt.key-file,
+// or of goboot.encrypt.key (e.g. GOBOOT_ENCRYPT_KEY), and returns the keys which were decrypted. goboot.encrypt.key
+// is removed from the settings once it is read, so it is neither served by the env actuator nor injectable.
+func (app *GobootApplication) decryptProperties(settings map[string]any) ([]string, error) {
+	v := viper.New()
+	v.MergeConfigMap(settings)
+	var key []byte
+	var err error
+	switch {
+	case v.IsSet(goboot_encrypt_key_file_property_name):
+		key, err = encrypt.ReadKeyFile(v.GetString(goboot_encrypt_key_file_property_name))
+	case v.IsSet(goboot_encrypt_key_property_name):
+		key, err = encrypt.ParseKey(v.GetString(goboot_encrypt_key_property_name))
+	}
+	environment.RemoveProperty(settings, goboot_encrypt_key_property_name)
+	if err != nil {
+		return nil, &environment.DecryptError{Err: err}
+	}
+	var encryptor *encrypt.TextEncryptor
+	if key != nil {
+		if encryptor, err = encrypt.NewTextEncryptor(key); err != nil {
+			return nil, &environment.DecryptError{Err: err}
+		}
+	}
+	return environment.DecryptProperties(settings, encryptor)
+}
@@ -0,0 +1,90 @@
+package goboot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sjexpos/goboot/encrypt"
+	"github.com/sjexpos/goboot/environment"
+)
+
+func newTestKey(t *testing.T) (string, *encrypt.TextEncryptor) {
+	encoded, _ := encrypt.GenerateKey()
+	key, _ := encrypt.ParseKey(encoded)
+	encryptor, err := encrypt.NewTextEncryptor(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded, encryptor
+}
+
+func TestEncryptedProperties(t *testing.T) {
+	t.Chdir(t.TempDir())
+	encodedKey, encryptor := newTestKey(t)
+	password, _ := encryptor.Encrypt("s3cr3t")
+	token, _ := encryptor.Encrypt("t0k3n")
+	t.Setenv("GOBOOT_ENCRYPT_KEY", encodedKey)
+	writeConfigFile(t, "application.yaml", "datasource:\n  password: '"+password+"'\n  url: postgres://app:${datasource.password}@db\n  dsn: ${datasource.url}\nclients:\n  - name: billing\n    credentials: '"+token+"'\n")
+
+	app, _ := NewGobootApplication()
+	v, _, err := app.prepareEnvironment()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value := v.GetString("datasource.password"); value != "s3cr3t" {
+		t.Errorf("datasource.password should be decrypted, got %v", value)
+	}
+	clients := v.Get("clients").([]any)
+	if credentials := clients[0].(map[string]any)["credentials"]; credentials != "t0k3n" {
+		t.Errorf("Encrypted values in lists should be decrypted, got %v", credentials)
+	}
+	if value := v.GetString("datasource.dsn"); value != "postgres://app:s3cr3t@db" {
+		t.Errorf("Placeholders should refer to the decrypted values, got %v", value)
+	}
+	if !app.origins.IsSensitive("datasource.password") || !app.origins.IsSensitive("clients") || app.origins.IsSensitive("datasource.host") {
+		t.Errorf("Decrypted properties should be sensitive")
+	}
+	if !app.origins.IsSensitive("datasource.url") || !app.origins.IsSensitive("datasource.dsn") {
+		t.Errorf("Properties referring to decrypted values should be sensitive")
+	}
+	if v.IsSet("goboot.encrypt.key") || !app.origins.IsSensitive("goboot.encrypt.key") {
+		t.Errorf("The encryption key should be removed from the environment")
+	}
+}
+
+func TestEncryptedPropertiesWithKeyFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	encodedKey, encryptor := newTestKey(t)
+	password, _ := encryptor.Encrypt("s3cr3t")
+	writeConfigFile(t, "goboot.key", encodedKey)
+	writeConfigFile(t, "application.yaml", "goboot:\n  encrypt:\n    key-file: goboot.key\ndatasource:\n  password: '"+password+"'\n")
+
+	app, _ := NewGobootApplication()
+	v, _, err := app.prepareEnvironment()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value := v.GetString("datasource.password"); value != "s3cr3t" {
+		t.Errorf("datasource.password should be decrypted, got %v", value)
+	}
+}
+
+func TestEncryptedPropertiesWithoutKey(t *testing.T) {
+	t.Chdir(t.TempDir())
+	_, encryptor := newTestKey(t)
+	password, _ := encryptor.Encrypt("s3cr3t")
+	writeConfigFile(t, "application.yaml", "datasource:\n  password: '"+password+"'\n")
+
+	app, _ := NewGobootApplication()
+	_, _, err := app.prepareEnvironment()
+
+	var decryptErr *environment.DecryptError
+	if !errors.As(err, &decryptErr) || decryptErr.Property != "datasource.password" || !errors.Is(err, environment.ErrNoDecryptionKey) {
+		t.Fatalf("Expected a decrypt error for datasource.password, got %v", err)
+	}
+	analysis := analyzeFailure(newFailureAnalyzers(nil), err)
+	if analysis == nil || analysis.ExitCode != EXIT_CODE_CONFIG {
+		t.Errorf("Unexpected failure analysis %+v", analysis)
+	}
+}
@@ -0,0 +1,65 @@
+package environment
+
+import (
+	"strings"
+)
+
+const optionArgPrefix = "--"
+const optionArgFlagValue = "true"
+
+// ApplicationArguments are the command-line arguments the application was started with.
+// Option arguments like --server.port=8081 are also properties with the highest precedence,
+// a flag without value (--debug) is the property "true".
+type ApplicationArguments struct {
+	SourceArgs    []string
+	OptionArgs    map[string][]string
+	NonOptionArgs []string
+}
+
+func ParseArguments(args []string) *ApplicationArguments {
+	arguments := &ApplicationArguments{
+		SourceArgs:    args,
+		OptionArgs:    make(map[string][]string),
+		NonOptionArgs: []string{},
+	}
+	for i, arg := range args {
+		if arg == optionArgPrefix {
+			// everything after "--" is a non option argument
+			arguments.NonOptionArgs = append(arguments.NonOptionArgs, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, optionArgPrefix) {
+			arguments.NonOptionArgs = append(arguments.NonOptionArgs, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, optionArgPrefix), "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			arguments.NonOptionArgs = append(arguments.NonOptionArgs, arg)
+			continue
+		}
+		if !hasValue {
+			value = optionArgFlagValue
+		}
+		arguments.OptionArgs[name] = append(arguments.OptionArgs[name], value)
+	}
+	return arguments
+}
+
+func (a *ApplicationArguments) ContainsOption(name string) bool {
+	_, found := a.OptionArgs[name]
+	return found
+}
+
+func (a *ApplicationArguments) OptionValues(name string) []string {
+	return a.OptionArgs[name]
+}
+
+// Properties returns the option arguments as properties, the last value wins when an option is repeated.
+func (a *ApplicationArguments) Properties() map[string]string {
+	properties := make(map[string]string, len(a.OptionArgs))
+	for name, values := range a.OptionArgs {
+		properties[strings.ToLower(name)] = values[len(values)-1]
+	}
+	return properties
+}
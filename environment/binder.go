@@ -0,0 +1,180 @@
+package environment
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+const bindKeyTagName = "mapstructure"
+const bindDefaultTagName = "default"
+
+var propertiesValidator = newPropertiesValidator()
+
+func newPropertiesValidator() *validator.Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	// field errors are reported with the property keys instead of the Go field names
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _ := propertyFieldName(field)
+		return name
+	})
+	return validate
+}
+
+// BindError is returned when the properties under Prefix can not be bound or are not valid.
+type BindError struct {
+	Prefix string
+	Err    error
+}
+
+func (e *BindError) Error() string {
+	return fmt.Sprintf("configuration properties '%v' %v", e.Prefix, e.Err)
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// Bind copies the properties under prefix (e.g. "datasource") into target, which must be a pointer to a struct.
+// Field keys are taken from the `mapstructure` tag (or the lower-cased field name), a `default` tag is used when
+// the property is not set and `validate` tags are checked once the struct was populated.
+func Bind(v *viper.Viper, prefix string, target any) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("configuration properties '%v' must be bound to a pointer to struct, got %T", prefix, target)
+	}
+	input := collectProperties(v, prefix, targetValue.Elem().Type())
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			stringToDataSizeHookFunc(),
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
+			mapstructure.StringToSliceHookFunc(","),
+		),
+		WeaklyTypedInput: true,
+		Squash:           true,
+		Result:           target,
+		TagName:          bindKeyTagName,
+	})
+	if err != nil {
+		return err
+	}
+	if err = decoder.Decode(input); err != nil {
+		return &BindError{Prefix: prefix, Err: fmt.Errorf("cannot be bound: %w", err)}
+	}
+	if err = propertiesValidator.Struct(target); err != nil {
+		return &BindError{Prefix: prefix, Err: fmt.Errorf("are not valid: %w", err)}
+	}
+	return nil
+}
+
+// collectProperties walks the struct type and reads every leaf key from the environment one by one, so
+// values coming from environment variables are taken into account (viper does not merge them into sub trees).
+func collectProperties(v *viper.Viper, prefix string, structType reflect.Type) map[string]any {
+	values := make(map[string]any)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, squash := propertyFieldName(field)
+		if squash {
+			for k, value := range collectProperties(v, prefix, field.Type) {
+				values[k] = value
+			}
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && fieldType.PkgPath() != "time" {
+			values[name] = collectProperties(v, key, fieldType)
+		} else if v.IsSet(key) {
+			values[name] = v.Get(key)
+		} else if relaxedKey, found := relaxedPropertyKey(v, key); found {
+			// an environment variable of a key no config file nor metadata knows, like DATASOURCE_SCHEMA_NAME,
+			// is set as datasource.schema.name
+			values[name] = v.Get(relaxedKey)
+		} else if defaultValue, found := field.Tag.Lookup(bindDefaultTagName); found {
+			values[name] = defaultValue
+		}
+	}
+	return values
+}
+
+// relaxedPropertyKey returns the key of the environment which has the same environment variable name as key.
+func relaxedPropertyKey(v *viper.Viper, key string) (string, bool) {
+	envName := CanonicalEnvName(key)
+	for _, candidate := range v.AllKeys() {
+		if CanonicalEnvName(candidate) == envName {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+func propertyFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get(bindKeyTagName)
+	name, options, _ := strings.Cut(tag, ",")
+	if options == "squash" || (field.Anonymous && name == "") {
+		return "", true
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, false
+}
+
+// BoundProperties are configuration properties bound under Prefix, Value returns the bound struct.
+// They are listed by the configprops actuator.
+type BoundProperties struct {
+	Prefix string
+	Value  func() any
+}
+
+// Properties returns the leaf properties of the bound struct by their full key, e.g. datasource.pool.max_idle.connections.
+func (b *BoundProperties) Properties() map[string]any {
+	properties := make(map[string]any)
+	flattenStruct(reflect.ValueOf(b.Value()), b.Prefix, properties)
+	return properties
+}
+
+func flattenStruct(value reflect.Value, key string, properties map[string]any) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			properties[key] = nil
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct || value.Type() == reflect.TypeFor[time.Time]() {
+		if duration, isDuration := value.Interface().(time.Duration); isDuration {
+			properties[key] = duration.String()
+			return
+		}
+		properties[key] = value.Interface()
+		return
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, squash := propertyFieldName(field)
+		childKey := key
+		if !squash {
+			childKey = key + "." + name
+		}
+		flattenStruct(value.Field(i), childKey, properties)
+	}
+}
@@ -0,0 +1,79 @@
+package environment
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type poolProperties struct {
+	Size    int           `mapstructure:"size" default:"5"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type testProperties struct {
+	Host       string         `mapstructure:"host" validate:"required"`
+	SchemaName string         `mapstructure:"schema_name"`
+	Port       int            `mapstructure:"port" default:"5432"`
+	Tags       []string       `mapstructure:"tags"`
+	Pool       poolProperties `mapstructure:"pool"`
+	Debug      bool
+}
+
+func newTestEnvironment(t *testing.T, yaml string) *viper.Viper {
+	v := viper.New()
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.SetConfigType("yaml")
+	if err := v.MergeConfig(bytes.NewReader([]byte(yaml))); err != nil {
+		t.Fatalf("Invalid yaml: %v", err)
+	}
+	return v
+}
+
+func TestBind(t *testing.T) {
+	t.Setenv("TEST_POOL_TIMEOUT", "2s")
+	v := newTestEnvironment(t, "test:\n  host: localhost\n  tags: a,b\n  debug: true\n")
+
+	var properties testProperties
+	if err := Bind(v, "test", &properties); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if properties.Host != "localhost" || properties.Port != 5432 || !properties.Debug {
+		t.Errorf("Unexpected properties: %+v", properties)
+	}
+	if len(properties.Tags) != 2 || properties.Tags[1] != "b" {
+		t.Errorf("Unexpected tags: %v", properties.Tags)
+	}
+	if properties.Pool.Size != 5 || properties.Pool.Timeout != 2*time.Second {
+		t.Errorf("Unexpected pool: %+v", properties.Pool)
+	}
+}
+
+func TestBindValidation(t *testing.T) {
+	v := newTestEnvironment(t, "test:\n  port: 1\n")
+
+	var properties testProperties
+	err := Bind(v, "test", &properties)
+	if err == nil || !strings.Contains(err.Error(), "'host'") {
+		t.Fatalf("A validation error about host was expected, got %v", err)
+	}
+}
+
+func TestBindEnvironmentVariableOfUnknownKey(t *testing.T) {
+	settings := map[string]any{"test": map[string]any{"host": "localhost"}}
+	ApplyEnvironmentVariables(settings, []string{"TEST_SCHEMA_NAME=shop"})
+	v := viper.New()
+	v.MergeConfigMap(settings)
+
+	var properties testProperties
+	if err := Bind(v, "test", &properties); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if properties.SchemaName != "shop" {
+		t.Errorf("schema_name should be bound from TEST_SCHEMA_NAME, got %q", properties.SchemaName)
+	}
+}
@@ -0,0 +1,55 @@
+package environment
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigTreeProperty is a property read from a config tree, FileName is the file it comes from.
+type ConfigTreeProperty struct {
+	Key      string
+	Value    string
+	FileName string
+}
+
+// ReadConfigTree reads the properties of a config tree, as mounted by Kubernetes or Docker for secrets and config maps:
+// every file is a property whose key is its path relative to dir, with '.' instead of the separators, and whose value
+// is its content without the trailing new line. /run/secrets/datasource.password and /run/secrets/datasource/password
+// are both datasource.password. Hidden files and directories (e.g. the ..data of Kubernetes) are skipped.
+func ReadConfigTree(dir string) ([]ConfigTreeProperty, error) {
+	properties := []ConfigTreeProperty{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			// a symbolic link to a directory or a broken one
+			return nil
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		value := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+		key := strings.ToLower(strings.ReplaceAll(filepath.ToSlash(relative), "/", "."))
+		properties = append(properties, ConfigTreeProperty{Key: key, Value: value, FileName: path})
+		return nil
+	})
+	return properties, err
+}
@@ -0,0 +1,46 @@
+package environment
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadConfigTree(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"datasource.password":   "secret\n",
+		"datasource/username":   "admin",
+		"Server/Port":           "8081\n",
+		"..data/ignored":        "ignored",
+		".hidden":               "ignored",
+		"management/.gitignore": "ignored",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Cannot create the directory of %v: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Cannot write %v: %v", name, err)
+		}
+	}
+
+	properties, err := ReadConfigTree(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"datasource.password": "secret",
+		"datasource.username": "admin",
+		"server.port":         "8081",
+	}
+	if len(properties) != len(expected) {
+		t.Fatalf("Expected %v properties, got %v", len(expected), properties)
+	}
+	for _, property := range properties {
+		if value, found := expected[property.Key]; !found || value != property.Value {
+			t.Errorf("Unexpected property %v=%q", property.Key, property.Value)
+		}
+	}
+}
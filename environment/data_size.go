@@ -0,0 +1,73 @@
+package environment
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// DataSize is a size in bytes, written in the configuration as 512B, 64KB, 10MB, 1GB or 2TB (a plain number is bytes).
+type DataSize int64
+
+const (
+	BYTES     DataSize = 1
+	KILOBYTES          = 1024 * BYTES
+	MEGABYTES          = 1024 * KILOBYTES
+	GIGABYTES          = 1024 * MEGABYTES
+	TERABYTES          = 1024 * GIGABYTES
+)
+
+var dataSizePattern = regexp.MustCompile(`^([+-]?\d+)\s*([a-zA-Z]*)$`)
+
+var dataSizeUnits = map[string]DataSize{
+	"":   BYTES,
+	"B":  BYTES,
+	"KB": KILOBYTES,
+	"MB": MEGABYTES,
+	"GB": GIGABYTES,
+	"TB": TERABYTES,
+}
+
+func ParseDataSize(text string) (DataSize, error) {
+	matches := dataSizePattern.FindStringSubmatch(strings.TrimSpace(text))
+	if matches == nil {
+		return 0, fmt.Errorf("'%v' is not a valid data size", text)
+	}
+	unit, found := dataSizeUnits[strings.ToUpper(matches[2])]
+	if !found {
+		return 0, fmt.Errorf("'%v' is not a valid data size, unknown unit '%v'", text, matches[2])
+	}
+	amount, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("'%v' is not a valid data size, %w", text, err)
+	}
+	return DataSize(amount) * unit, nil
+}
+
+func (s DataSize) Bytes() int64 {
+	return int64(s)
+}
+
+func (s DataSize) String() string {
+	for _, unit := range []string{"TB", "GB", "MB", "KB"} {
+		size := dataSizeUnits[unit]
+		if s != 0 && s%size == 0 {
+			return fmt.Sprintf("%v%v", int64(s/size), unit)
+		}
+	}
+	return fmt.Sprintf("%vB", int64(s))
+}
+
+// stringToDataSizeHookFunc lets Bind decode "10MB" into DataSize fields.
+func stringToDataSizeHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || to != reflect.TypeOf(DataSize(0)) {
+			return data, nil
+		}
+		return ParseDataSize(data.(string))
+	}
+}
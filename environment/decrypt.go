@@ -0,0 +1,88 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sjexpos/goboot/encrypt"
+)
+
+// ErrNoDecryptionKey is the cause of a DecryptError when there are encrypted values but no key to decrypt them.
+var ErrNoDecryptionKey = errors.New("no decryption key is configured")
+
+// DecryptError is returned when the {cipher} value of Property can not be decrypted, it never holds the value.
+// Property is empty when the key itself is wrong.
+type DecryptError struct {
+	Property string
+	Err      error
+}
+
+func (e *DecryptError) Error() string {
+	if e.Property == "" {
+		return fmt.Sprintf("decryption key is not valid: %v", e.Err)
+	}
+	return fmt.Sprintf("property '%v' can not be decrypted: %v", e.Property, e.Err)
+}
+
+func (e *DecryptError) Unwrap() error {
+	return e.Err
+}
+
+// DecryptProperties replaces the {cipher} values of the settings, in maps and lists, with their decrypted text and
+// returns the keys which were decrypted. encryptor is nil when no key is configured.
+func DecryptProperties(settings map[string]any, encryptor *encrypt.TextEncryptor) ([]string, error) {
+	keys := []string{}
+	for name, value := range settings {
+		decrypted, err := decryptValue(name, value, encryptor, &keys)
+		if err != nil {
+			return nil, err
+		}
+		settings[name] = decrypted
+	}
+	return keys, nil
+}
+
+func decryptValue(key string, value any, encryptor *encrypt.TextEncryptor, keys *[]string) (any, error) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for name, child := range typed {
+			decrypted, err := decryptValue(key+"."+name, child, encryptor, keys)
+			if err != nil {
+				return nil, err
+			}
+			typed[name] = decrypted
+		}
+	case map[any]any:
+		// yaml maps nested in lists are not normalized by viper
+		for name, child := range typed {
+			decrypted, err := decryptValue(fmt.Sprintf("%v.%v", key, name), child, encryptor, keys)
+			if err != nil {
+				return nil, err
+			}
+			typed[name] = decrypted
+		}
+	case []any:
+		for i, item := range typed {
+			decrypted, err := decryptValue(fmt.Sprintf("%v[%v]", key, i), item, encryptor, keys)
+			if err != nil {
+				return nil, err
+			}
+			typed[i] = decrypted
+		}
+	case string:
+		if !encrypt.IsEncrypted(typed) {
+			return value, nil
+		}
+		if encryptor == nil {
+			return nil, &DecryptError{Property: key, Err: ErrNoDecryptionKey}
+		}
+		text, err := encryptor.Decrypt(strings.TrimSpace(typed))
+		if err != nil {
+			return nil, &DecryptError{Property: key, Err: err}
+		}
+		*keys = append(*keys, key)
+		return text, nil
+	}
+	return value, nil
+}
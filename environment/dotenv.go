@@ -0,0 +1,71 @@
+package environment
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadDotenvFile reads a .env file, see ParseDotenv.
+func ReadDotenvFile(fileName string) ([]string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseDotenv(file)
+}
+
+// ParseDotenv parses the variables of a .env file and returns them as NAME=value items, like os.Environ().
+// Lines are NAME=value or export NAME=value, # starts a comment, values can be single-quoted (taken as is)
+// or double-quoted (with \n, \t, \" and \\ escapes).
+func ParseDotenv(r io.Reader) ([]string, error) {
+	environ := []string{}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("line %v is not NAME=value", lineNumber)
+		}
+		value, err := parseDotenvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", lineNumber, err)
+		}
+		environ = append(environ, name+"="+value)
+	}
+	return environ, scanner.Err()
+}
+
+var dotenvEscapes = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`)
+
+func parseDotenvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	quote := value[0]
+	if quote != '"' && quote != '\'' {
+		// an unquoted value ends at an inline comment
+		if index := strings.Index(value, " #"); index >= 0 {
+			value = value[:index]
+		}
+		return strings.TrimSpace(value), nil
+	}
+	end := strings.LastIndexByte(value, quote)
+	if end == 0 {
+		return "", fmt.Errorf("value %v is not closed", value[:1])
+	}
+	if quote == '\'' {
+		return value[1:end], nil
+	}
+	return dotenvEscapes.Replace(value[1:end]), nil
+}
@@ -0,0 +1,38 @@
+package environment
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	content := `# database
+DATASOURCE_HOST=db.local # the host
+export DATASOURCE_PORT=5433
+
+DATASOURCE_PASSWORD='s3cr#t ${x}'
+APPLICATION_DESCRIPTION="first line\nsecond \"line\""
+EMPTY=
+`
+	environ, err := ParseDotenv(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{
+		"DATASOURCE_HOST=db.local",
+		"DATASOURCE_PORT=5433",
+		"DATASOURCE_PASSWORD=s3cr#t ${x}",
+		"APPLICATION_DESCRIPTION=first line\nsecond \"line\"",
+		"EMPTY=",
+	}
+	if !slices.Equal(environ, expected) {
+		t.Errorf("Expected %q, got %q", expected, environ)
+	}
+
+	for _, invalid := range []string{"NO_VALUE", "=value", "QUOTED=\"not closed"} {
+		if _, err := ParseDotenv(strings.NewReader(invalid)); err == nil {
+			t.Errorf("%q should not be parsed", invalid)
+		}
+	}
+}
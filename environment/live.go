@@ -0,0 +1,31 @@
+package environment
+
+import (
+	"sync/atomic"
+
+	"github.com/spf13/viper"
+)
+
+// LiveEnvironment holds the current environment. When the configuration is reloaded (config.watch.enabled) a new,
+// fully built, environment replaces the current one atomically: environments are never changed once they are
+// published, so they can be read from any goroutine. The *viper.Viper provided to the beans is the environment at
+// startup, beans which need the reloaded values use the LiveEnvironment (or a Refreshable).
+type LiveEnvironment struct {
+	current atomic.Pointer[viper.Viper]
+}
+
+func NewLiveEnvironment(v *viper.Viper) *LiveEnvironment {
+	live := &LiveEnvironment{}
+	live.current.Store(v)
+	return live
+}
+
+// Get returns the current environment, callers must not change it.
+func (l *LiveEnvironment) Get() *viper.Viper {
+	return l.current.Load()
+}
+
+// Set publishes v as the current environment.
+func (l *LiveEnvironment) Set(v *viper.Viper) {
+	l.current.Store(v)
+}
@@ -0,0 +1,138 @@
+package environment
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// Origins of the properties which do not come from a file.
+const (
+	ORIGIN_DEFAULTS              = "embedded default.yaml"
+	ORIGIN_MODULE_DEFAULTS       = "embedded defaults of module %v"
+	ORIGIN_DEFAULT_PROPERTIES    = "default properties"
+	ORIGIN_PROPERTY_SOURCE       = "property source %v"
+	ORIGIN_BUILD_INFO            = "build info"
+	ORIGIN_ENVIRONMENT_VARIABLE  = "environment variable %v"
+	ORIGIN_COMMAND_LINE_ARGUMENT = "command-line argument --%v"
+	ORIGIN_CONFIG_FILE           = "config file %v"
+	ORIGIN_CONFIG_TREE           = "config tree file %v"
+	ORIGIN_DOTENV                = "variable %v of dotenv file %v"
+	ORIGIN_APPLICATION           = "application"
+)
+
+// PropertyOrigins records the property source the value of each property comes from, e.g. "config file ./application.yaml"
+// or "environment variable SERVER_PORT". A source recorded later overrides the previous ones, like its values do.
+type PropertyOrigins struct {
+	mutex     sync.RWMutex
+	origins   map[string]string
+	sensitive map[string]bool
+}
+
+func NewPropertyOrigins() *PropertyOrigins {
+	return &PropertyOrigins{origins: make(map[string]string), sensitive: make(map[string]bool)}
+}
+
+// Record sets the origin of the property key.
+func (o *PropertyOrigins) Record(key string, origin string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.origins[strings.ToLower(key)] = origin
+}
+
+// RecordAll sets the origin of every property of the settings, lists are recorded as one property.
+func (o *PropertyOrigins) RecordAll(settings map[string]any, origin string) {
+	for key := range FlattenSettings(settings) {
+		o.Record(key, origin)
+	}
+}
+
+// Origin returns the origin of the property key, or of the closest parent key which has one (e.g. the list
+// open-api-v3.servers for open-api-v3.servers[0].url), or "" when it is unknown.
+func (o *PropertyOrigins) Origin(key string) string {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	key = strings.ToLower(key)
+	for key != "" {
+		if origin, found := o.origins[key]; found {
+			return origin
+		}
+		index := strings.LastIndexAny(key, ".[")
+		if index < 0 {
+			break
+		}
+		key = key[:index]
+	}
+	return ""
+}
+
+// MarkSensitive records that the value of key must never be shown, e.g. because it was decrypted.
+func (o *PropertyOrigins) MarkSensitive(key string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.sensitive[strings.ToLower(key)] = true
+}
+
+// IsSensitive reports whether key, one of its parents or one of its children was marked sensitive.
+func (o *PropertyOrigins) IsSensitive(key string) bool {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	key = strings.ToLower(key)
+	for sensitive := range o.sensitive {
+		if sensitive == key || isChildKey(key, sensitive) || isChildKey(sensitive, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func isChildKey(key string, parent string) bool {
+	return strings.HasPrefix(key, parent+".") || strings.HasPrefix(key, parent+"[")
+}
+
+// Replace replaces the origins with the ones of other, e.g. once the configuration was reloaded.
+func (o *PropertyOrigins) Replace(other *PropertyOrigins) {
+	other.mutex.RLock()
+	origins := maps.Clone(other.origins)
+	sensitive := maps.Clone(other.sensitive)
+	other.mutex.RUnlock()
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.origins = origins
+	o.sensitive = sensitive
+}
+
+// FlattenSettings returns the leaf properties of the settings (e.g. viper.AllSettings()) by their dotted key,
+// lists are leaf values.
+func FlattenSettings(settings map[string]any) map[string]any {
+	flat := make(map[string]any)
+	flattenSettings(settings, "", flat)
+	return flat
+}
+
+func flattenSettings(value any, key string, flat map[string]any) {
+	var children map[string]any
+	switch typed := value.(type) {
+	case map[string]any:
+		children = typed
+	case map[any]any:
+		children = make(map[string]any, len(typed))
+		for childKey, child := range typed {
+			children[fmt.Sprint(childKey)] = child
+		}
+	default:
+		flat[key] = value
+		return
+	}
+	if len(children) == 0 && key != "" {
+		flat[key] = value
+		return
+	}
+	for childKey, child := range children {
+		if key != "" {
+			childKey = key + "." + childKey
+		}
+		flattenSettings(child, childKey, flat)
+	}
+}
@@ -0,0 +1,33 @@
+package environment
+
+import "testing"
+
+func TestPropertyOrigins(t *testing.T) {
+	origins := NewPropertyOrigins()
+	origins.RecordAll(map[string]any{
+		"server":      map[string]any{"port": 4242, "shutdown": "graceful"},
+		"open-api-v3": map[string]any{"servers": []any{map[string]any{"url": "http://a"}}},
+	}, ORIGIN_DEFAULTS)
+	origins.RecordAll(map[string]any{"server": map[string]any{"port": 8080}}, "config file ./application.yaml")
+	origins.Record("SERVER.SHUTDOWN", "environment variable SERVER_SHUTDOWN")
+
+	expected := map[string]string{
+		"server.port":                "config file ./application.yaml",
+		"server.shutdown":            "environment variable SERVER_SHUTDOWN",
+		"open-api-v3.servers[0].url": ORIGIN_DEFAULTS,
+		"open-api-v3.api-docs.path":  "",
+		"management.server.port":     "",
+	}
+	for key, origin := range expected {
+		if got := origins.Origin(key); got != origin {
+			t.Errorf("Origin of %v should be %q, got %q", key, origin, got)
+		}
+	}
+
+	reloaded := NewPropertyOrigins()
+	reloaded.Record("server.port", "config file ./other.yaml")
+	origins.Replace(reloaded)
+	if origins.Origin("server.shutdown") != "" || origins.Origin("server.port") != "config file ./other.yaml" {
+		t.Errorf("Origins were not replaced")
+	}
+}
@@ -0,0 +1,182 @@
+package environment
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const placeholderPrefix = "${"
+const placeholderSuffix = "}"
+const placeholderValueSeparator = ":"
+
+// PlaceholderError is returned when a ${...} placeholder can not be resolved or refers to itself.
+type PlaceholderError struct {
+	Property    string
+	Placeholder string
+	Cycle       []string
+}
+
+func (e *PlaceholderError) Error() string {
+	if len(e.Cycle) > 0 {
+		return fmt.Sprintf("circular placeholder reference '%v' in property '%v' (%v)", e.Placeholder, e.Property, strings.Join(e.Cycle, " -> "))
+	}
+	return fmt.Sprintf("could not resolve placeholder '%v' in property '%v'", e.Placeholder, e.Property)
+}
+
+// ResolvePlaceholders returns all the settings of the environment, where every ${key} or ${key:default}
+// found in string values is replaced by the value of the property key, or of the environment variable key
+// (e.g. ${HOME}), or by default when the property is not set. A value made only of one placeholder keeps the
+// type of the property it refers to. Placeholders which can not be resolved in the values of lenientKeys (e.g. the
+// ones set by environment variables, which may hold unrelated ${...} text) are kept as they are.
+func ResolvePlaceholders(v *viper.Viper, lenientKeys []string) (map[string]any, error) {
+	settings, _, err := ResolveSensitivePlaceholders(v, lenientKeys, nil)
+	return settings, err
+}
+
+// ResolveSensitivePlaceholders resolves the placeholders like ResolvePlaceholders and also returns the properties
+// whose value refers to one of the sensitiveKeys (e.g. the decrypted ones), directly or through other properties.
+func ResolveSensitivePlaceholders(v *viper.Viper, lenientKeys []string, sensitiveKeys []string) (map[string]any, []string, error) {
+	resolver := &placeholderResolver{environment: v, lenientKeys: lenientKeys, sensitive: make(map[string]bool)}
+	for _, key := range sensitiveKeys {
+		resolver.sensitive[strings.ToLower(key)] = true
+	}
+	resolved, err := resolver.resolveValue("", v.AllSettings())
+	if err != nil {
+		return nil, nil, err
+	}
+	return resolved.(map[string]any), resolver.sensitiveReferences, nil
+}
+
+type placeholderResolver struct {
+	environment *viper.Viper
+	lenientKeys []string
+	resolving   []string
+	// sensitive are the sensitive keys and the ones found to refer to them, sensitiveReferences the latter
+	sensitive           map[string]bool
+	sensitiveReferences []string
+}
+
+// referenced records that property refers to key, property becomes sensitive when key is.
+func (r *placeholderResolver) referenced(property string, key string) {
+	key = strings.ToLower(key)
+	if r.sensitive[key] && !r.sensitive[property] {
+		r.sensitive[property] = true
+		r.sensitiveReferences = append(r.sensitiveReferences, property)
+	}
+}
+
+func (r *placeholderResolver) resolveValue(property string, value any) (any, error) {
+	switch typed := value.(type) {
+	case string:
+		resolved, err := r.resolveString(property, typed)
+		if err != nil && slices.Contains(r.lenientKeys, property) {
+			return typed, nil
+		}
+		return resolved, err
+	case map[string]any:
+		resolved := make(map[string]any, len(typed))
+		for k, item := range typed {
+			key := k
+			if property != "" {
+				key = property + "." + k
+			}
+			resolvedItem, err := r.resolveValue(key, item)
+			if err != nil {
+				return nil, err
+			}
+			resolved[k] = resolvedItem
+		}
+		return resolved, nil
+	case map[any]any:
+		// yaml maps nested in lists are not normalized by viper
+		normalized := make(map[string]any, len(typed))
+		for k, item := range typed {
+			normalized[fmt.Sprint(k)] = item
+		}
+		return r.resolveValue(property, normalized)
+	case []any:
+		resolved := make([]any, len(typed))
+		for i, item := range typed {
+			resolvedItem, err := r.resolveValue(fmt.Sprintf("%v[%v]", property, i), item)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = resolvedItem
+		}
+		return resolved, nil
+	}
+	return value, nil
+}
+
+func (r *placeholderResolver) resolveString(property string, value string) (any, error) {
+	start := strings.Index(value, placeholderPrefix)
+	if start < 0 {
+		return value, nil
+	}
+	end := findPlaceholderEnd(value, start)
+	if end < 0 {
+		return value, nil
+	}
+	placeholder := value[start+len(placeholderPrefix) : end]
+	resolved, err := r.resolvePlaceholder(property, placeholder)
+	if err != nil {
+		return nil, err
+	}
+	if start == 0 && end == len(value)-len(placeholderSuffix) {
+		return resolved, nil
+	}
+	rest, err := r.resolveString(property, value[end+len(placeholderSuffix):])
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("%v%v%v", value[:start], resolved, rest), nil
+}
+
+func (r *placeholderResolver) resolvePlaceholder(property string, placeholder string) (any, error) {
+	// the key itself may be built from other placeholders, e.g. ${datasource.${profile}.host}
+	resolvedPlaceholder, err := r.resolveString(property, placeholder)
+	if err != nil {
+		return nil, err
+	}
+	key, defaultValue, hasDefault := strings.Cut(fmt.Sprint(resolvedPlaceholder), placeholderValueSeparator)
+	key = strings.TrimSpace(key)
+	if slices.Contains(r.resolving, key) {
+		return nil, &PlaceholderError{Property: property, Placeholder: placeholder, Cycle: append(r.resolving, key)}
+	}
+	if r.environment.IsSet(key) {
+		r.resolving = append(r.resolving, key)
+		defer func() { r.resolving = r.resolving[:len(r.resolving)-1] }()
+		value, err := r.resolveValue(key, r.environment.Get(key))
+		r.referenced(property, key)
+		return value, err
+	}
+	if value, found := os.LookupEnv(key); found {
+		return value, nil
+	}
+	if hasDefault {
+		return r.resolveString(property, defaultValue)
+	}
+	return nil, &PlaceholderError{Property: property, Placeholder: placeholder}
+}
+
+// findPlaceholderEnd returns the position of the suffix closing the placeholder starting at start,
+// taking nested placeholders into account, or -1 when it is not closed.
+func findPlaceholderEnd(value string, start int) int {
+	depth := 0
+	for i := start + len(placeholderPrefix); i < len(value); i++ {
+		if strings.HasPrefix(value[i:], placeholderPrefix) {
+			depth++
+			i += len(placeholderPrefix) - 1
+		} else if strings.HasPrefix(value[i:], placeholderSuffix) {
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
@@ -0,0 +1,69 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestResolvePlaceholders(t *testing.T) {
+	t.Setenv("PLACEHOLDER_TEST_USER", "admin")
+	v := newTestEnvironment(t, `
+application:
+  name: shop
+server:
+  port: 8080
+open-api-v3:
+  info:
+    title: ${application.name}
+    description: ${application.name} (${application.version:snapshot}) by ${PLACEHOLDER_TEST_USER}
+  servers:
+    - url: http://localhost:${server.port}
+management:
+  server:
+    port: ${server.port}
+`)
+	settings, err := ResolvePlaceholders(v, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	info := settings["open-api-v3"].(map[string]any)["info"].(map[string]any)
+	if info["title"] != "shop" {
+		t.Errorf("Unexpected title: %v", info["title"])
+	}
+	if info["description"] != "shop (snapshot) by admin" {
+		t.Errorf("Unexpected description: %v", info["description"])
+	}
+	servers := settings["open-api-v3"].(map[string]any)["servers"].([]any)
+	if url := servers[0].(map[string]any)["url"]; url != "http://localhost:8080" {
+		t.Errorf("Unexpected server url: %v", url)
+	}
+	if port := settings["management"].(map[string]any)["server"].(map[string]any)["port"]; port != 8080 {
+		t.Errorf("A single placeholder should keep the int type, got %#v", port)
+	}
+}
+
+func TestResolveEnvironmentVariablePlaceholders(t *testing.T) {
+	t.Setenv("PLACEHOLDER_TEST_HOME", "/home/shop")
+	v := viper.New()
+	v.MergeConfigMap(map[string]any{"logs": "${PLACEHOLDER_TEST_HOME}/logs"})
+	settings, err := ResolvePlaceholders(v, nil)
+	if err != nil || settings["logs"] != "/home/shop/logs" {
+		t.Errorf("The environment variable should be resolved, got %v (%v)", settings["logs"], err)
+	}
+}
+
+func TestResolvePlaceholdersErrors(t *testing.T) {
+	var placeholderErr *PlaceholderError
+
+	_, err := ResolvePlaceholders(newTestEnvironment(t, "a: ${missing.key}\n"), nil)
+	if !errors.As(err, &placeholderErr) || placeholderErr.Property != "a" || len(placeholderErr.Cycle) > 0 {
+		t.Errorf("An unresolved placeholder error was expected, got %v", err)
+	}
+
+	_, err = ResolvePlaceholders(newTestEnvironment(t, "a: ${b}\nb: x${c}\nc: ${b}\n"), nil)
+	if !errors.As(err, &placeholderErr) || len(placeholderErr.Cycle) == 0 {
+		t.Errorf("A circular placeholder error was expected, got %v", err)
+	}
+}
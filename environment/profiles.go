@@ -0,0 +1,44 @@
+package environment
+
+import (
+	"slices"
+	"strings"
+)
+
+const DEFAULT_PROFILE = "default"
+
+// Profiles are the profiles active in the environment, in the order their
+// application-{profile}.yaml files were merged (last one wins).
+type Profiles []string
+
+func ParseProfiles(value any) Profiles {
+	var items []string
+	switch v := value.(type) {
+	case string:
+		items = strings.Split(v, ",")
+	case []string:
+		items = v
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				items = append(items, strings.Split(s, ",")...)
+			}
+		}
+	}
+	profiles := Profiles{}
+	for _, item := range items {
+		profile := strings.TrimSpace(item)
+		if profile != "" && !slices.Contains(profiles, profile) {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+func (p Profiles) IsActive(profile string) bool {
+	return slices.Contains(p, profile)
+}
+
+func (p Profiles) String() string {
+	return strings.Join(p, ", ")
+}
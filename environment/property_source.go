@@ -0,0 +1,35 @@
+package environment
+
+// PropertySource is a named set of properties added to the environment by the application, e.g. with
+// goboot.New().WithPropertySource(...). Its keys can be dotted, e.g. server.port.
+type PropertySource interface {
+	Name() string
+	Properties() (map[string]any, error)
+}
+
+// MapPropertySource is a PropertySource holding the properties of a map.
+type MapPropertySource struct {
+	name       string
+	properties map[string]any
+}
+
+func NewMapPropertySource(name string, properties map[string]any) *MapPropertySource {
+	return &MapPropertySource{name: name, properties: properties}
+}
+
+func (s *MapPropertySource) Name() string {
+	return s.name
+}
+
+func (s *MapPropertySource) Properties() (map[string]any, error) {
+	return s.properties, nil
+}
+
+// PropertySettings returns the properties, whose keys can be dotted, as nested settings, like the ones of a configuration file.
+func PropertySettings(properties map[string]any) map[string]any {
+	settings := make(map[string]any)
+	for key, value := range properties {
+		SetProperty(settings, key, value)
+	}
+	return settings
+}
@@ -0,0 +1,65 @@
+package environment
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+
+	"github.com/spf13/viper"
+)
+
+// ConfigChangedEvent is delivered to every ConfigChangedListener after the configuration files
+// were reloaded. Keys are the leaf property keys whose values were added, updated or removed.
+type ConfigChangedEvent struct {
+	Keys        []string
+	Environment *viper.Viper
+}
+
+// HasChanged reports if the property key, or any property under it, has changed.
+func (e *ConfigChangedEvent) HasChanged(key string) bool {
+	for _, changed := range e.Keys {
+		if changed == key || strings.HasPrefix(changed, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+type ConfigChangedListener interface {
+	OnConfigChanged(event *ConfigChangedEvent)
+}
+
+// Refreshable holds configuration properties bound under a prefix which are bound again, and swapped
+// atomically, every time one of its properties changes. Callers must not keep the value returned by Get.
+type Refreshable[T any] struct { // implements ConfigChangedListener
+	prefix string
+	value  atomic.Pointer[T]
+}
+
+func NewRefreshable[T any](v *viper.Viper, prefix string) (*Refreshable[T], error) {
+	properties := new(T)
+	if err := Bind(v, prefix, properties); err != nil {
+		return nil, err
+	}
+	refreshable := &Refreshable[T]{prefix: prefix}
+	refreshable.value.Store(properties)
+	return refreshable, nil
+}
+
+func (r *Refreshable[T]) Get() *T {
+	return r.value.Load()
+}
+
+func (r *Refreshable[T]) OnConfigChanged(event *ConfigChangedEvent) {
+	if !event.HasChanged(r.prefix) {
+		return
+	}
+	properties := new(T)
+	if err := Bind(event.Environment, r.prefix, properties); err != nil {
+		slog.Warn(fmt.Sprintf("Configuration properties '%v' were not refreshed, previous values are kept", r.prefix), slog.Any("error", err))
+		return
+	}
+	r.value.Store(properties)
+	slog.Info(fmt.Sprintf("Configuration properties '%v' were refreshed", r.prefix))
+}
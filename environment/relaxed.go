@@ -0,0 +1,274 @@
+package environment
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var envNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+var envNameReplacer = strings.NewReplacer(".", "_", "-", "_", "[", "_", "]", "")
+
+// CanonicalEnvName returns the environment variable which sets the property key, e.g.
+// open-api-v3.api-docs.path is OPEN_API_V3_API_DOCS_PATH and open-api-v3.servers[0].url is OPEN_API_V3_SERVERS_0_URL.
+func CanonicalEnvName(key string) string {
+	return strings.ToUpper(envNameReplacer.Replace(key))
+}
+
+// ApplyEnvironmentVariables sets the environment variables (KEY=value items, like os.Environ()) into the settings.
+// A variable whose name is the canonical form of an existing key or of one of the knownKeys (e.g. the metadata keys)
+// sets that key. The name of another variable is split on '_' into key parts, the parts which form an existing or
+// known key once they are joined with '_' or '-' are kept together (e.g. DATASOURCE_SCHEMA_NAME is datasource.schema_name
+// and OPEN_API_V3_INFO_TITLE is open-api-v3.info.title), and numeric parts are indexes of the existing lists
+// (e.g. SERVERS_2_URL is servers[2].url when servers has 2 items). It returns the keys which were set.
+func ApplyEnvironmentVariables(settings map[string]any, environ []string, knownKeys ...string) []string {
+	canonicalKeys := make(map[string][]any)
+	knownLists := make(map[string]bool)
+	flattenPropertyPaths(settings, nil, canonicalKeys, knownLists)
+	knownPaths := make(map[string]bool)
+	for _, path := range canonicalKeys {
+		addKnownPath(knownPaths, path)
+	}
+	for _, key := range knownKeys {
+		path := parsePropertyPath(key)
+		if _, found := canonicalKeys[CanonicalEnvName(key)]; !found && len(path) > 0 {
+			canonicalKeys[CanonicalEnvName(key)] = path
+		}
+		addKnownPath(knownPaths, path)
+	}
+	names := []string{}
+	values := make(map[string]string)
+	for _, item := range environ {
+		name, value, found := strings.Cut(item, "=")
+		if found && envNamePattern.MatchString(name) {
+			names = append(names, name)
+			values[name] = value
+		}
+	}
+	slices.Sort(names)
+	keys := []string{}
+	for _, name := range names {
+		path, known := canonicalKeys[name]
+		if !known {
+			path = relaxedPropertyPath(strings.Split(strings.ToLower(name), "_"), knownPaths, knownLists)
+		}
+		if setPropertyPath(settings, path, values[name]) {
+			keys = append(keys, formatPropertyPath(path))
+		} else {
+			slog.Debug(fmt.Sprintf("Environment variable %v was not mapped to a property, it conflicts with an existing one or is past the end of a list", name))
+		}
+	}
+	return keys
+}
+
+// relaxedPropertyPath converts the parts of an environment variable name to a key path. At each position the longest
+// run of parts which, joined with '_' or '-', continues a known key is taken, otherwise a single part is taken.
+func relaxedPropertyPath(parts []string, knownPaths map[string]bool, knownLists map[string]bool) []any {
+	path := []any{}
+	for i := 0; i < len(parts); {
+		if parts[i] == "" {
+			i++
+			continue
+		}
+		if index, err := strconv.Atoi(parts[i]); err == nil && knownLists[formatPropertyPath(path)] {
+			path = append(path, index)
+			i++
+			continue
+		}
+		part, next := parts[i], i+1
+	candidates:
+		for end := len(parts); end > i+1; end-- {
+			for _, separator := range []string{"_", "-"} {
+				candidate := strings.Join(parts[i:end], separator)
+				if knownPaths[knownPathKey(append(slices.Clone(path), candidate))] {
+					part, next = candidate, end
+					break candidates
+				}
+			}
+		}
+		path = append(path, part)
+		i = next
+	}
+	return path
+}
+
+// addKnownPath adds the key of path and of its parents, list indexes are left out so any item of a list matches.
+func addKnownPath(knownPaths map[string]bool, path []any) {
+	for i := 1; i <= len(path); i++ {
+		knownPaths[knownPathKey(path[:i])] = true
+	}
+}
+
+func knownPathKey(path []any) string {
+	key := strings.Builder{}
+	for _, part := range path {
+		switch typed := part.(type) {
+		case int:
+			key.WriteString("[]")
+		default:
+			if key.Len() > 0 {
+				key.WriteString(".")
+			}
+			key.WriteString(fmt.Sprint(typed))
+		}
+	}
+	return key.String()
+}
+
+// SetProperty sets the value of a key like datasource.host or open-api-v3.servers[0].url into the settings.
+func SetProperty(settings map[string]any, key string, value any) bool {
+	return setPropertyPath(settings, parsePropertyPath(key), value)
+}
+
+// RemoveProperty removes a key like goboot.encrypt.key from the settings, it returns false when it is not set.
+func RemoveProperty(settings map[string]any, key string) bool {
+	path := parsePropertyPath(key)
+	current := settings
+	for i, part := range path {
+		name, ok := part.(string)
+		if !ok {
+			return false
+		}
+		if i == len(path)-1 {
+			_, found := current[name]
+			delete(current, name)
+			return found
+		}
+		if current, ok = current[name].(map[string]any); !ok {
+			return false
+		}
+	}
+	return false
+}
+
+func parsePropertyPath(key string) []any {
+	path := []any{}
+	for _, part := range strings.Split(strings.ToLower(key), ".") {
+		name, rest, hasIndex := strings.Cut(part, "[")
+		if name != "" {
+			path = append(path, name)
+		}
+		for hasIndex {
+			var indexText string
+			indexText, rest, _ = strings.Cut(rest, "]")
+			if index, err := strconv.Atoi(indexText); err == nil {
+				path = append(path, index)
+			}
+			_, rest, hasIndex = strings.Cut(rest, "[")
+		}
+	}
+	return path
+}
+
+func formatPropertyPath(path []any) string {
+	key := strings.Builder{}
+	for _, part := range path {
+		switch typed := part.(type) {
+		case int:
+			key.WriteString(fmt.Sprintf("[%v]", typed))
+		default:
+			if key.Len() > 0 {
+				key.WriteString(".")
+			}
+			key.WriteString(fmt.Sprint(typed))
+		}
+	}
+	return key.String()
+}
+
+func flattenPropertyPaths(value any, path []any, keys map[string][]any, lists map[string]bool) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for k, item := range typed {
+			flattenPropertyPaths(item, append(slices.Clone(path), k), keys, lists)
+		}
+	case map[any]any:
+		for k, item := range typed {
+			flattenPropertyPaths(item, append(slices.Clone(path), fmt.Sprint(k)), keys, lists)
+		}
+	case []any:
+		lists[formatPropertyPath(path)] = true
+		for i, item := range typed {
+			flattenPropertyPaths(item, append(slices.Clone(path), i), keys, lists)
+		}
+	default:
+		if len(path) > 0 {
+			keys[CanonicalEnvName(formatPropertyPath(path))] = path
+		}
+	}
+}
+
+// setPropertyPath sets the value at path, creating the missing maps and lists. Nested maps and lists are copied
+// before they are changed. It returns false when the path goes through an existing value which is not a map or a list,
+// or through a list index greater than the length of the list (lists only grow by one item at the end).
+func setPropertyPath(settings map[string]any, path []any, value any) bool {
+	if len(path) == 0 {
+		return false
+	}
+	key, ok := path[0].(string)
+	if !ok {
+		return false
+	}
+	if len(path) == 1 {
+		if _, isMap := settings[key].(map[string]any); isMap {
+			return false
+		}
+		settings[key] = value
+		return true
+	}
+	child, ok := setPropertyChild(settings[key], path[1:], value)
+	if ok {
+		settings[key] = child
+	}
+	return ok
+}
+
+func setPropertyChild(current any, path []any, value any) (any, bool) {
+	switch index := path[0].(type) {
+	case int:
+		var list []any
+		switch typed := current.(type) {
+		case nil:
+			list = []any{}
+		case []any:
+			list = slices.Clone(typed)
+		default:
+			return nil, false
+		}
+		if index < 0 || index > len(list) {
+			return nil, false
+		}
+		if index == len(list) {
+			list = append(list, nil)
+		}
+		if len(path) == 1 {
+			list[index] = value
+			return list, true
+		}
+		child, ok := setPropertyChild(list[index], path[1:], value)
+		if ok {
+			list[index] = child
+		}
+		return list, ok
+	default:
+		var m map[string]any
+		switch typed := current.(type) {
+		case nil:
+			m = make(map[string]any)
+		case map[string]any:
+			m = maps.Clone(typed)
+		case map[any]any:
+			m = make(map[string]any, len(typed))
+			for k, item := range typed {
+				m[fmt.Sprint(k)] = item
+			}
+		default:
+			return nil, false
+		}
+		return m, setPropertyPath(m, path, value)
+	}
+}
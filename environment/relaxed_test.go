@@ -0,0 +1,110 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestApplyEnvironmentVariables(t *testing.T) {
+	v := newTestEnvironment(t, `
+open-api-v3:
+  api-docs:
+    path: /api
+  servers:
+    - url: http://one
+      description: first
+    - url: http://two
+server:
+  port: 4242
+`)
+	settings := v.AllSettings()
+	keys := ApplyEnvironmentVariables(settings, []string{
+		"OPEN_API_V3_API_DOCS_PATH=/v3/api-docs",
+		"OPEN_API_V3_SERVERS_0_URL=http://changed",
+		"OPEN_API_V3_SERVERS_2_URL=http://three",
+		"OPEN_API_V3_SERVERS_20250101_URL=http://far",
+		"OPEN_API_V3_INFO_TITLE=Shop",
+		"FEATURES_0=search",
+		"BACKUP_20250101=x",
+		"PATH=/usr/bin",
+		"SERVER_PORT_NUMBER=1",
+		"lower_case=ignored",
+	})
+	if len(keys) != 7 {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+
+	result := viper.New()
+	result.MergeConfigMap(settings)
+	if path := result.GetString("open-api-v3.api-docs.path"); path != "/v3/api-docs" {
+		t.Errorf("Hyphenated key was not set, got %v", path)
+	}
+	servers := result.Get("open-api-v3.servers").([]any)
+	first := servers[0].(map[string]any)
+	if len(servers) != 3 || first["url"] != "http://changed" || first["description"] != "first" {
+		t.Errorf("List item was not set in place or appended: %v", servers)
+	}
+	if title := result.GetString("open-api-v3.info.title"); title != "Shop" {
+		t.Errorf("Unknown key under a known map was not set, got %v", title)
+	}
+	// numeric parts of the variables outside of the existing lists are keys, not indexes
+	if result.GetString("features.0") != "search" || result.GetString("backup.20250101") != "x" || result.GetString("path") != "/usr/bin" {
+		t.Errorf("Variables outside of the existing properties should be kept, got %v", result.AllSettings())
+	}
+	if port := result.GetInt("server.port"); port != 4242 {
+		t.Errorf("Conflicting variable should be ignored, server.port is %v", port)
+	}
+}
+
+func TestApplyEnvironmentVariablesWithUnderscoreKeys(t *testing.T) {
+	v := newTestEnvironment(t, `
+datasource:
+  host: localhost
+  pool:
+    max_idle:
+      connections: 10
+`)
+	settings := v.AllSettings()
+	keys := ApplyEnvironmentVariables(settings, []string{
+		"DATASOURCE_SCHEMA_NAME=shop",
+		"DATASOURCE_POOL_MAX_IDLE_CONNECTIONS=5",
+		"DATASOURCE_POOL_MAX_OPEN_CONNECTIONS=50",
+		"GORM_OPEN_SESSION_IN_VIEW_ENABLED=false",
+		"APP_FEATURE_FLAG=on",
+	}, "datasource.schema_name", "datasource.pool.max_open.connections", "gorm.open-session-in-view.enabled")
+	if len(keys) != 5 {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+
+	result := viper.New()
+	result.MergeConfigMap(settings)
+	expected := map[string]string{
+		"datasource.schema_name":               "shop",
+		"datasource.pool.max_idle.connections": "5",
+		"datasource.pool.max_open.connections": "50",
+		// a known key without any config file parent
+		"gorm.open-session-in-view.enabled": "false",
+		// an unknown key without any config file parent
+		"app.feature.flag": "on",
+	}
+	for key, value := range expected {
+		if got := result.GetString(key); got != value {
+			t.Errorf("%v should be %q, got %q", key, value, got)
+		}
+	}
+	for _, key := range []string{"datasource.schema.name", "datasource.pool.max.open.connections", "gorm.open.session.in.view.enabled"} {
+		if result.IsSet(key) {
+			t.Errorf("%v should not be set", key)
+		}
+	}
+}
+
+func TestApplyEnvironmentVariablesUnderKnownParent(t *testing.T) {
+	settings := map[string]any{}
+	// the parts of the name are joined like the known key they continue, the rest is split
+	keys := ApplyEnvironmentVariables(settings, []string{"OPEN_API_V3_INFO_X_LOGO_URL=logo.png"}, "open-api-v3.info")
+	if len(keys) != 1 || keys[0] != "open-api-v3.info.x.logo.url" {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+}
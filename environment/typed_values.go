@@ -0,0 +1,116 @@
+package environment
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// TypedValues returns every typed view of a property value, one per Go type, e.g. "10MB" is a string, a
+// []string and a DataSize while 8080 (or "8080" from an environment variable) is an int, an int64, a float64,
+// a DataSize and a string. The string view is always there, so a value like "1h" can still be used as a label.
+func TypedValues(value any) []any {
+	switch typed := value.(type) {
+	case string:
+		return stringTypedValues(typed)
+	case int:
+		return []any{typed, int64(typed), float64(typed), DataSize(typed), strconv.Itoa(typed)}
+	case int64:
+		return []any{int(typed), typed, float64(typed), DataSize(typed), strconv.FormatInt(typed, 10)}
+	case float64:
+		return []any{typed, strconv.FormatFloat(typed, 'f', -1, 64)}
+	case bool:
+		return []any{typed, strconv.FormatBool(typed)}
+	case time.Time:
+		return []any{typed, typed.Format(time.RFC3339Nano)}
+	case []any:
+		return listTypedValues(typed)
+	case []string:
+		return []any{typed}
+	}
+	return []any{}
+}
+
+func stringTypedValues(value string) []any {
+	values := []any{value}
+	text := strings.TrimSpace(value)
+	if intValue, err := strconv.ParseInt(text, 10, 64); err == nil {
+		values = append(values, int(intValue), intValue)
+	}
+	if floatValue, err := strconv.ParseFloat(text, 64); err == nil {
+		values = append(values, floatValue)
+	}
+	if boolValue, err := strconv.ParseBool(text); err == nil {
+		values = append(values, boolValue)
+	}
+	if durationValue, err := time.ParseDuration(text); err == nil {
+		values = append(values, durationValue)
+	}
+	if sizeValue, err := ParseDataSize(text); err == nil {
+		values = append(values, sizeValue)
+	}
+	for _, layout := range timeLayouts {
+		if timeValue, err := time.Parse(layout, text); err == nil {
+			values = append(values, timeValue)
+			break
+		}
+	}
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		items = append(items, strings.TrimSpace(item))
+	}
+	values = append(values, items)
+	if ints, ok := toInts(items); ok {
+		values = append(values, ints)
+	}
+	return values
+}
+
+func listTypedValues(list []any) []any {
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		switch item.(type) {
+		case map[string]any, map[any]any, []any:
+			// lists of objects are only reachable through *viper.Viper or configuration properties
+			return []any{}
+		}
+		items = append(items, fmt.Sprint(item))
+	}
+	values := []any{items}
+	if ints, ok := toInts(items); ok {
+		values = append(values, ints)
+	}
+	return values
+}
+
+func toInts(items []string) ([]int, bool) {
+	ints := make([]int, 0, len(items))
+	for _, item := range items {
+		intValue, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, false
+		}
+		ints = append(ints, intValue)
+	}
+	return ints, true
+}
+
+// StringMapValue returns the scalar children of a map property as a map[string]string, nested maps and lists are skipped.
+func StringMapValue(value any) (map[string]string, bool) {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	result := make(map[string]string)
+	for k, item := range m {
+		switch item.(type) {
+		case map[string]any, map[any]any, []any, nil:
+			continue
+		}
+		result[k] = fmt.Sprint(item)
+	}
+	return result, len(result) > 0
+}
@@ -0,0 +1,34 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/viper"
+)
+
+// EnvironmentPrepared is published once the environment is prepared, before any other component is invoked.
+type EnvironmentPrepared struct {
+	Environment *viper.Viper
+	Profiles    environment.Profiles
+}
+
+// ApplicationStarted is published once every OnStart hook ran, the HTTP listeners are already up.
+type ApplicationStarted struct {
+	TimeTaken time.Duration
+}
+
+// ApplicationReady is published when the application is ready to service requests, it is the last startup event.
+type ApplicationReady struct {
+	TimeTaken time.Duration
+}
+
+// ApplicationFailed is published when the application fails to start, Err is the startup error.
+type ApplicationFailed struct {
+	Err error
+}
+
+// ContextClosing is published when a shutdown was requested, before any OnStop hook runs.
+type ContextClosing struct {
+	Signal string
+}
@@ -0,0 +1,89 @@
+package event
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/sjexpos/goboot/core"
+)
+
+// ApplicationEventPublisher delivers an event, any value, to the application listeners.
+// Goboot publishes the lifecycle events (e.g. *ApplicationReady), applications can publish their own events.
+type ApplicationEventPublisher interface {
+	PublishEvent(event any)
+}
+
+// ApplicationListener receives every published event, use Listener to only receive the events of a type.
+// Listeners which implement core.Ordered are called first, by ascending order.
+type ApplicationListener interface {
+	OnApplicationEvent(event any)
+}
+
+type typedListener[T any] struct {
+	f func(T)
+}
+
+func (l *typedListener[T]) OnApplicationEvent(event any) {
+	if typed, ok := event.(T); ok {
+		l.f(typed)
+	}
+}
+
+// Listener returns an ApplicationListener which calls f with the events of type T, e.g.
+//
+//	event.Listener(func(e *event.ApplicationReady) { ... })
+func Listener[T any](f func(T)) ApplicationListener {
+	return &typedListener[T]{f: f}
+}
+
+type asyncListener struct {
+	ApplicationListener
+}
+
+func (l *asyncListener) GetOrder() int {
+	if ordered, ok := l.ApplicationListener.(core.Ordered); ok {
+		return ordered.GetOrder()
+	}
+	return core.ORDERED_LOWEST_PRECEDENCE
+}
+
+// Async returns a listener which receives the events in its own go routine, so PublishEvent does not wait for it.
+func Async(listener ApplicationListener) ApplicationListener {
+	return &asyncListener{ApplicationListener: listener}
+}
+
+// SimpleApplicationEventPublisher calls the listeners in order, synchronous listeners in the publishing
+// go routine and asynchronous ones (see Async) in a new go routine.
+type SimpleApplicationEventPublisher struct { // implements ApplicationEventPublisher
+	listeners []ApplicationListener
+	pending   sync.WaitGroup
+}
+
+func NewApplicationEventPublisher(listeners []ApplicationListener) *SimpleApplicationEventPublisher {
+	return &SimpleApplicationEventPublisher{listeners: core.SortByOrder(listeners)}
+}
+
+func (p *SimpleApplicationEventPublisher) PublishEvent(event any) {
+	for _, listener := range p.listeners {
+		if async, ok := listener.(*asyncListener); ok {
+			p.pending.Add(1)
+			go func() {
+				defer p.pending.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						slog.Error(fmt.Sprintf("Async listener failed to handle %T: %v", event, r))
+					}
+				}()
+				async.OnApplicationEvent(event)
+			}()
+			continue
+		}
+		listener.OnApplicationEvent(event)
+	}
+}
+
+// Wait blocks until the asynchronous listeners handled every published event.
+func (p *SimpleApplicationEventPublisher) Wait() {
+	p.pending.Wait()
+}
@@ -0,0 +1,65 @@
+package event
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+type orderedListener struct {
+	order    int
+	received *[]int
+}
+
+func (l *orderedListener) OnApplicationEvent(event any) {
+	if _, ok := event.(*ApplicationReady); ok {
+		*l.received = append(*l.received, l.order)
+	}
+}
+
+func (l *orderedListener) GetOrder() int {
+	return l.order
+}
+
+func TestListenersOrder(t *testing.T) {
+	received := []int{}
+	publisher := NewApplicationEventPublisher([]ApplicationListener{
+		Listener(func(*ApplicationReady) { received = append(received, 100) }),
+		&orderedListener{order: 2, received: &received},
+		&orderedListener{order: 1, received: &received},
+	})
+
+	publisher.PublishEvent(&ApplicationStarted{})
+	publisher.PublishEvent(&ApplicationReady{})
+
+	if !slices.Equal(received, []int{1, 2, 100}) {
+		t.Errorf("Unexpected delivery order %v", received)
+	}
+}
+
+type OrderPlaced struct {
+	ID string
+}
+
+func TestAsyncListener(t *testing.T) {
+	mutex := sync.Mutex{}
+	received := []string{}
+	release := make(chan struct{})
+	publisher := NewApplicationEventPublisher([]ApplicationListener{
+		Async(Listener(func(e *OrderPlaced) {
+			<-release
+			mutex.Lock()
+			defer mutex.Unlock()
+			received = append(received, e.ID)
+		})),
+	})
+
+	// the publisher does not wait for the async listener
+	publisher.PublishEvent(&OrderPlaced{ID: "1"})
+	close(release)
+	publisher.Wait()
+
+	if !slices.Equal(received, []string{"1"}) {
+		t.Errorf("Unexpected events %v", received)
+	}
+}
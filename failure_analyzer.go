@@ -0,0 +1,269 @@
+package goboot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sjexpos/goboot/datasource"
+	"github.com/sjexpos/goboot/environment"
+	"github.com/sjexpos/goboot/runner"
+	"github.com/spf13/viper"
+)
+
+const EXIT_CODE_FAILURE = 1
+const EXIT_CODE_UNAVAILABLE = 69 // sysexits EX_UNAVAILABLE, a port or a service needed by the application is not available
+const EXIT_CODE_CONFIG = 78      // sysexits EX_CONFIG, the configuration of the application is missing or wrong
+
+// FailureAnalysis is the human-readable explanation of an error which stopped the application from starting.
+type FailureAnalysis struct {
+	Description string
+	Action      string
+	ExitCode    int
+	Cause       error
+}
+
+// FailureAnalyzer explains a startup error, it returns nil when the error is not one it knows about.
+type FailureAnalyzer interface {
+	Analyze(err error) *FailureAnalysis
+}
+
+func newFailureAnalyzers(v *viper.Viper) []FailureAnalyzer {
+	return []FailureAnalyzer{
+		&configDataNotFoundFailureAnalyzer{},
+		&placeholderFailureAnalyzer{},
+		&decryptFailureAnalyzer{},
+		&bindFailureAnalyzer{},
+		&portInUseFailureAnalyzer{},
+		&datasourceFailureAnalyzer{},
+		&missingDependencyFailureAnalyzer{environment: v},
+		&runnerFailureAnalyzer{},
+	}
+}
+
+func analyzeFailure(analyzers []FailureAnalyzer, err error) *FailureAnalysis {
+	for _, analyzer := range analyzers {
+		analysis := analyzer.Analyze(err)
+		if analysis != nil {
+			analysis.Cause = err
+			return analysis
+		}
+	}
+	return nil
+}
+
+func printFailureAnalysis(out io.Writer, analysis *FailureAnalysis) {
+	fmt.Fprintln(out)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "***************************")
+	fmt.Fprintln(out, "APPLICATION FAILED TO START")
+	fmt.Fprintln(out, "***************************")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Description:")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, analysis.Description)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Action:")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, analysis.Action)
+	fmt.Fprintln(out)
+}
+
+type configDataNotFoundFailureAnalyzer struct {
+}
+
+func (a *configDataNotFoundFailureAnalyzer) Analyze(err error) *FailureAnalysis {
+	var notFoundErr *configDataNotFoundError
+	if !errors.As(err, &notFoundErr) {
+		return nil
+	}
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("Config data location '%v' does not exist, it is required by %v.", notFoundErr.Location, notFoundErr.Origin),
+		Action:      fmt.Sprintf("Check that the value '%v' is correct, or prefix it with 'optional:'.", notFoundErr.Location),
+		ExitCode:    EXIT_CODE_CONFIG,
+	}
+}
+
+type placeholderFailureAnalyzer struct {
+}
+
+func (a *placeholderFailureAnalyzer) Analyze(err error) *FailureAnalysis {
+	var placeholderErr *environment.PlaceholderError
+	if !errors.As(err, &placeholderErr) {
+		return nil
+	}
+	if len(placeholderErr.Cycle) > 0 {
+		return &FailureAnalysis{
+			Description: fmt.Sprintf("The value of property '%v' can not be resolved, placeholder '${%v}' is part of a cycle:\n\n    %v", placeholderErr.Property, placeholderErr.Placeholder, strings.Join(placeholderErr.Cycle, " -> ")),
+			Action:      "Update your configuration so the properties do not refer to each other.",
+			ExitCode:    EXIT_CODE_CONFIG,
+		}
+	}
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("The value of property '%v' can not be resolved, placeholder '${%v}' refers to a property which is not set.", placeholderErr.Property, placeholderErr.Placeholder),
+		Action:      fmt.Sprintf("Define the property used in '${%v}', or give the placeholder a default value with '${key:default}'.", placeholderErr.Placeholder),
+		ExitCode:    EXIT_CODE_CONFIG,
+	}
+}
+
+type decryptFailureAnalyzer struct {
+}
+
+func (a *decryptFailureAnalyzer) Analyze(err error) *FailureAnalysis {
+	var decryptErr *environment.DecryptError
+	if !errors.As(err, &decryptErr) {
+		return nil
+	}
+	if decryptErr.Property == "" {
+		return &FailureAnalysis{
+			Description: fmt.Sprintf("The key to decrypt the {cipher} values is not valid: %v.", decryptErr.Err),
+			Action:      fmt.Sprintf("Set %v or %v to a base64 AES key of 16, 24 or 32 bytes, e.g. one made with 'goboot encrypt -generate-key'.", goboot_encrypt_key_file_property_name, environment.CanonicalEnvName(goboot_encrypt_key_property_name)),
+			ExitCode:    EXIT_CODE_CONFIG,
+		}
+	}
+	if errors.Is(decryptErr.Err, environment.ErrNoDecryptionKey) {
+		return &FailureAnalysis{
+			Description: fmt.Sprintf("The value of property '%v' is encrypted, but no key to decrypt it is configured.", decryptErr.Property),
+			Action:      fmt.Sprintf("Set %v to the file of the key, or the %v environment variable to the key.", goboot_encrypt_key_file_property_name, environment.CanonicalEnvName(goboot_encrypt_key_property_name)),
+			ExitCode:    EXIT_CODE_CONFIG,
+		}
+	}
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("The value of property '%v' can not be decrypted: %v.", decryptErr.Property, decryptErr.Err),
+		Action:      "Check that the value was encrypted with the configured key, e.g. with 'goboot encrypt'.",
+		ExitCode:    EXIT_CODE_CONFIG,
+	}
+}
+
+type bindFailureAnalyzer struct {
+}
+
+func (a *bindFailureAnalyzer) Analyze(err error) *FailureAnalysis {
+	var bindErr *environment.BindError
+	if !errors.As(err, &bindErr) {
+		return nil
+	}
+	var validationErrs validator.ValidationErrors
+	if !errors.As(bindErr, &validationErrs) {
+		return &FailureAnalysis{
+			Description: fmt.Sprintf("Failed to bind properties under '%v':\n\n    %v", bindErr.Prefix, errors.Unwrap(bindErr.Err)),
+			Action:      fmt.Sprintf("Update the properties under '%v' in your configuration so they match the expected types.", bindErr.Prefix),
+			ExitCode:    EXIT_CODE_CONFIG,
+		}
+	}
+	description := strings.Builder{}
+	description.WriteString(fmt.Sprintf("Binding to properties under '%v' failed:\n", bindErr.Prefix))
+	for _, fieldErr := range validationErrs {
+		// the namespace starts with the struct name, the remaining part are the property keys
+		_, path, _ := strings.Cut(fieldErr.Namespace(), ".")
+		description.WriteString(fmt.Sprintf("\n    Property: %v.%v\n    Value: '%v'\n    Reason: failed on the '%v' rule\n", bindErr.Prefix, path, fieldErr.Value(), fieldErr.Tag()))
+	}
+	return &FailureAnalysis{
+		Description: description.String(),
+		Action:      fmt.Sprintf("Update the properties under '%v' in your configuration.", bindErr.Prefix),
+		ExitCode:    EXIT_CODE_CONFIG,
+	}
+}
+
+type portInUseFailureAnalyzer struct {
+}
+
+func (a *portInUseFailureAnalyzer) Analyze(err error) *FailureAnalysis {
+	if !errors.Is(err, syscall.EADDRINUSE) {
+		return nil
+	}
+	address := "the configured address"
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Addr != nil {
+		address = opErr.Addr.String()
+	}
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("Web server failed to start. Address %v was already in use.", address),
+		Action:      "Identify and stop the process that's listening on that port or configure this application to listen on another one (server.port, management.server.port).",
+		ExitCode:    EXIT_CODE_UNAVAILABLE,
+	}
+}
+
+type datasourceFailureAnalyzer struct {
+}
+
+func (a *datasourceFailureAnalyzer) Analyze(err error) *FailureAnalysis {
+	var connectionErr *datasource.ConnectionError
+	if !errors.As(err, &connectionErr) {
+		return nil
+	}
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("Failed to configure a datasource: the database at %v:%v could not be reached.\n\n    Reason: %v", connectionErr.Host, connectionErr.Port, connectionErr.Err),
+		Action:      "Check that the database is running and that datasource.host, datasource.port, datasource.username and datasource.password are right.",
+		ExitCode:    EXIT_CODE_UNAVAILABLE,
+	}
+}
+
+// missingDependencyFailureAnalyzer explains dig "missing type" errors, e.g. `missing type: int[name="server.port"]`.
+type missingDependencyFailureAnalyzer struct {
+	environment *viper.Viper
+}
+
+var missingTypesPattern = regexp.MustCompile(`missing types?: (.*)`)
+var missingTypePattern = regexp.MustCompile(`^([^\[\s]+)(?:\[name="([^"]+)"\])?`)
+
+func (a *missingDependencyFailureAnalyzer) Analyze(err error) *FailureAnalysis {
+	matches := missingTypesPattern.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return nil
+	}
+	description := strings.Builder{}
+	actions := strings.Builder{}
+	for _, missing := range strings.Split(matches[1], "; ") {
+		typeMatches := missingTypePattern.FindStringSubmatch(missing)
+		if typeMatches == nil {
+			continue
+		}
+		typeName, propertyName := typeMatches[1], typeMatches[2]
+		if propertyName == "" {
+			description.WriteString(fmt.Sprintf("A component required a value of type '%v' that could not be found.\n", typeName))
+			actions.WriteString(fmt.Sprintf("Consider providing a '%v' in your configuration, e.g. by adding the module which provides it.\n", typeName))
+		} else if a.environment.IsSet(propertyName) {
+			value := a.environment.Get(propertyName)
+			description.WriteString(fmt.Sprintf("Property '%v' has the value '%v' (%v), which can not be used as a %v.\n", propertyName, value, reflect.TypeOf(value), typeName))
+			actions.WriteString(fmt.Sprintf("Update '%v' in your configuration with a valid %v value.\n", propertyName, typeName))
+		} else {
+			description.WriteString(fmt.Sprintf("Property '%v' of type %v is required, but it is not set.\n", propertyName, typeName))
+			actions.WriteString(fmt.Sprintf("Define '%v' in application.yaml or set the %v environment variable.\n", propertyName, environment.CanonicalEnvName(propertyName)))
+		}
+	}
+	if description.Len() == 0 {
+		return nil
+	}
+	return &FailureAnalysis{
+		Description: strings.TrimSpace(description.String()),
+		Action:      strings.TrimSpace(actions.String()),
+		ExitCode:    EXIT_CODE_CONFIG,
+	}
+}
+
+type runnerFailureAnalyzer struct {
+}
+
+func (a *runnerFailureAnalyzer) Analyze(err error) *FailureAnalysis {
+	var runnerErr *runner.RunnerError
+	if !errors.As(err, &runnerErr) {
+		return nil
+	}
+	exitCode := EXIT_CODE_FAILURE
+	var generator runner.ExitCodeGenerator
+	if errors.As(err, &generator) {
+		exitCode = generator.GetExitCode()
+	}
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("The runner %v failed after the application was started:\n\n    %v", runnerErr.Runner, runnerErr.Err),
+		Action:      "Check the error returned by the runner, the application was stopped.",
+		ExitCode:    exitCode,
+	}
+}
@@ -0,0 +1,76 @@
+package goboot
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/sjexpos/goboot/runner"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+func TestMissingPropertyAnalysis(t *testing.T) {
+	v := viper.New()
+	v.Set("server.port", "not-a-number")
+	app := fx.New(
+		fx.NopLogger,
+		fx.Provide(fx.Annotate(func() string { return "not-a-number" }, fx.ResultTags(`name:"server.port"`))),
+		fx.Invoke(fx.Annotate(func(int, string) {}, fx.ParamTags(`name:"server.port"`, `name:"datasource.host"`))),
+	)
+
+	analysis := analyzeFailure(newFailureAnalyzers(v), app.Err())
+	if analysis == nil {
+		t.Fatalf("Missing properties should be analyzed: %v", app.Err())
+	}
+	if analysis.ExitCode != EXIT_CODE_CONFIG {
+		t.Errorf("Unexpected exit code %v", analysis.ExitCode)
+	}
+	if !strings.Contains(analysis.Description, "'server.port' has the value 'not-a-number'") {
+		t.Errorf("Type mismatch was not described: %v", analysis.Description)
+	}
+	if !strings.Contains(analysis.Description, "'datasource.host' of type string is required") {
+		t.Errorf("Missing property was not described: %v", analysis.Description)
+	}
+}
+
+func TestPortInUseAnalysis(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	defer ln.Close()
+	_, err = net.Listen("tcp", ln.Addr().String())
+
+	analysis := analyzeFailure(newFailureAnalyzers(viper.New()), err)
+	if analysis == nil || analysis.ExitCode != EXIT_CODE_UNAVAILABLE {
+		t.Fatalf("Port in use should be analyzed: %v", err)
+	}
+	if !strings.Contains(analysis.Description, ln.Addr().String()) {
+		t.Errorf("Address was not described: %v", analysis.Description)
+	}
+}
+
+type importError struct {
+	exitCode int
+}
+
+func (e *importError) Error() string {
+	return "import failed"
+}
+
+func (e *importError) GetExitCode() int {
+	return e.exitCode
+}
+
+func TestRunnerFailureAnalysis(t *testing.T) {
+	err := &runner.RunnerError{Runner: "*main.importRunner", Err: &importError{exitCode: 3}}
+
+	analysis := analyzeFailure(newFailureAnalyzers(viper.New()), err)
+	if analysis == nil || analysis.ExitCode != 3 {
+		t.Fatalf("Runner failure should be analyzed with the exit code of the error: %+v", analysis)
+	}
+	if !strings.Contains(analysis.Description, "*main.importRunner failed") {
+		t.Errorf("Runner was not described: %v", analysis.Description)
+	}
+}
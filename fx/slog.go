@@ -4,19 +4,37 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/sjexpos/goboot/beans"
+	"github.com/sjexpos/goboot/startup"
 	"go.uber.org/fx/fxevent"
 )
 
 // SlogLogger an Fx event logger that logs events using a slog logger.
 type SlogLogger struct {
 	Logger *slog.Logger
+	// DebugModules are the modules whose provided and decorated events are logged at debug level.
+	DebugModules []string
+	// Timeline records the constructors, decorators, invokes and OnStart hooks which ran and how long they took.
+	Timeline *startup.Timeline
+	// Beans records the provided and supplied values.
+	Beans *beans.Registry
 
 	ctx        context.Context
 	logLevel   slog.Level
 	errorLevel *slog.Level
+	// providedTypes are the types of each constructor by module, invoking is when the running invokes started
+	providedTypes map[constructorKey][]string
+	invoking      []time.Time
+}
+
+type constructorKey struct {
+	module string
+	name   string
 }
 
 // UseContext sets the context that will be used when logging to slog.
@@ -62,6 +80,14 @@ func (l *SlogLogger) logInfoEvent(msg string, fields ...any) {
 	l.Logger.InfoContext(l.ctx, msg, l.filter(fields)...)
 }
 
+func (l *SlogLogger) logModuleEvent(module string, msg string, fields ...any) {
+	if slices.Contains(l.DebugModules, module) {
+		l.logDebugEvent(msg, fields...)
+	} else {
+		l.logInfoEvent(msg, fields...)
+	}
+}
+
 func (l *SlogLogger) logError(msg string, fields ...any) {
 	lvl := slog.LevelError
 	if l.errorLevel != nil {
@@ -71,8 +97,62 @@ func (l *SlogLogger) logError(msg string, fields ...any) {
 	l.Logger.Log(l.ctx, lvl, msg, l.filter(fields)...)
 }
 
+// recordBean adds the provided and supplied values to the beans.
+func (l *SlogLogger) recordBean(event fxevent.Event) {
+	if l.Beans == nil {
+		return
+	}
+	switch e := event.(type) {
+	case *fxevent.Provided:
+		if e.Err == nil {
+			l.Beans.Record(e.ConstructorName, e.ModuleName, e.Private, e.OutputTypeNames)
+		}
+	case *fxevent.Supplied:
+		if e.Err == nil {
+			l.Beans.Record("fx.Supply", e.ModuleName, false, []string{e.TypeName})
+		}
+	}
+}
+
+// record adds the steps with a runtime to the timeline.
+func (l *SlogLogger) record(event fxevent.Event) {
+	if l.Timeline == nil {
+		return
+	}
+	if l.providedTypes == nil {
+		l.providedTypes = make(map[constructorKey][]string)
+	}
+	switch e := event.(type) {
+	case *fxevent.Provided:
+		l.providedTypes[constructorKey{module: e.ModuleName, name: e.ConstructorName}] = e.OutputTypeNames
+	case *fxevent.Run:
+		if e.Err == nil && e.Kind != "supply" {
+			types := l.providedTypes[constructorKey{module: e.ModuleName, name: e.Name}]
+			l.Timeline.Record(startup.Step{Name: e.Name, Kind: e.Kind, Module: e.ModuleName, Types: types, Duration: e.Runtime})
+		}
+	case *fxevent.Invoking:
+		l.invoking = append(l.invoking, time.Now())
+	case *fxevent.Invoked:
+		// every Invoked event follows the Invoking event of the same invoke
+		if len(l.invoking) == 0 {
+			return
+		}
+		invoking := l.invoking[len(l.invoking)-1]
+		l.invoking = l.invoking[:len(l.invoking)-1]
+		if e.Err == nil {
+			l.Timeline.Record(startup.Step{Name: e.FunctionName, Kind: startup.KIND_INVOKE, Module: e.ModuleName, Duration: time.Since(invoking)})
+		}
+	case *fxevent.OnStartExecuted:
+		if e.Err == nil {
+			l.Timeline.Record(startup.Step{Name: e.FunctionName, Kind: startup.KIND_ON_START, Module: e.CallerName, Duration: e.Runtime})
+		}
+	}
+}
+
 // LogEvent logs the given event to the provided Zap logger.
 func (l *SlogLogger) LogEvent(event fxevent.Event) {
+	l.record(event)
+	l.recordBean(event)
 	switch e := event.(type) {
 	case *fxevent.OnStartExecuting:
 		l.logDebugEvent("OnStart hook executing",
@@ -139,7 +219,7 @@ func (l *SlogLogger) LogEvent(event fxevent.Event) {
 			l.logError(strings.Join(e.StackTrace, "\n"))
 		} else {
 			for _, rtype := range e.OutputTypeNames {
-				l.logInfoEvent("provided",
+				l.logModuleEvent(e.ModuleName, "provided",
 					slog.String("type", rtype),
 					slog.String("constructor", e.ConstructorName),
 				)
@@ -172,7 +252,7 @@ func (l *SlogLogger) LogEvent(event fxevent.Event) {
 			l.logError(strings.Join(e.StackTrace, "\n"))
 		} else {
 			for _, rtype := range e.OutputTypeNames {
-				l.logInfoEvent("decorated",
+				l.logModuleEvent(e.ModuleName, "decorated",
 					slog.String("type", rtype),
 					slog.String("decorator", e.DecoratorName),
 				)
@@ -0,0 +1,61 @@
+package fx
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sjexpos/goboot/startup"
+	uber_fx "go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+type repository struct{}
+
+func newRepository() *repository {
+	return &repository{}
+}
+
+func useRepository(*repository) {}
+
+func startRepository() {}
+
+func TestSlogLoggerTimeline(t *testing.T) {
+	timeline := startup.NewTimeline(time.Now())
+	logger := &SlogLogger{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Timeline: timeline}
+	app := uber_fx.New(
+		uber_fx.WithLogger(func() fxevent.Logger { return logger }),
+		uber_fx.Provide(newRepository),
+		uber_fx.Module("users", uber_fx.Invoke(useRepository)),
+		uber_fx.Module("orders", uber_fx.Invoke(useRepository)),
+		uber_fx.Invoke(func(lc uber_fx.Lifecycle) {
+			lc.Append(uber_fx.StartHook(startRepository))
+		}),
+	)
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer app.Stop(context.Background())
+
+	found := map[string][]startup.Step{}
+	for _, step := range timeline.Steps() {
+		for _, name := range []string{"newRepository", "useRepository", "startRepository"} {
+			if strings.Contains(step.Name, name) {
+				found[name] = append(found[name], step)
+			}
+		}
+	}
+	if provide := found["newRepository"]; len(provide) != 1 || provide[0].Kind != startup.KIND_PROVIDE || len(provide[0].Types) != 1 || provide[0].Types[0] != "*fx.repository" {
+		t.Errorf("The constructor should be recorded with its type, got %+v", provide)
+	}
+	invokes := found["useRepository"]
+	if len(invokes) != 2 || invokes[0].Kind != startup.KIND_INVOKE || invokes[0].Module == invokes[1].Module {
+		t.Errorf("Both invokes of the same function should be recorded, got %+v", invokes)
+	}
+	if onStart := found["startRepository"]; len(onStart) != 1 || onStart[0].Kind != startup.KIND_ON_START {
+		t.Errorf("The OnStart hook should be recorded, got %+v", onStart)
+	}
+}
@@ -4,17 +4,28 @@ import (
 	"bytes"
 	"context"
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"os"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/mbndr/figlet4go"
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/availability"
+	"github.com/sjexpos/goboot/beans"
+	"github.com/sjexpos/goboot/buildinfo"
+	"github.com/sjexpos/goboot/environment"
+	"github.com/sjexpos/goboot/event"
 	goboot_fx "github.com/sjexpos/goboot/fx"
+	"github.com/sjexpos/goboot/lifecycle"
 	"github.com/sjexpos/goboot/log"
+	"github.com/sjexpos/goboot/runner"
+	"github.com/sjexpos/goboot/startup"
 	"github.com/spf13/viper"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
@@ -29,149 +40,561 @@ const banner_default_text string = "Banner"
 const application_banner_property_name = "application.banner"
 const application_log_property_name = "application.log"
 const application_name_property_name = "application.name"
+const application_version_property_name = "application.version"
+const application_mode_property_name = "application.mode"
+const application_mode_server = "server"
+const application_mode_batch = "batch"
+const goboot_profiles_active_property_name = "goboot.profiles.active"
+const goboot_profiles_default_property_name = "goboot.profiles.default"
+const goboot_lazy_initialization_property_name = "goboot.lazy-initialization"
 
+// Run runs the application until it receives a shutdown signal, then the process exits with the exit code of the
+// application (see ExitError).
 func Run(fxOpts ...fx.Option) {
-	// Initialize the application
 	app, err := NewGobootApplication(fxOpts...)
 	if err != nil {
-		panic(err) // Handle error appropriately, e.g., log it or return it
+		exit(err)
 	}
+	exit(app.Run())
+}
+
+// RunOnce runs the application in batch mode, whatever application.mode is: the application is started,
+// the runners are called and then it is stopped. The process exits with the code of the exit code generators.
+func RunOnce(fxOpts ...fx.Option) {
+	app, err := newBatchApplication(fxOpts...)
+	if err != nil {
+		exit(err)
+	}
+	exit(app.Run())
+}
+
+func newBatchApplication(fxOpts ...fx.Option) (*GobootApplication, error) {
+	app, err := NewGobootApplication(fxOpts...)
+	if err != nil {
+		return nil, err
+	}
+	app.mode = application_mode_batch
+	return app, nil
+}
+
+// osExit is replaced by the tests of Run and RunOnce.
+var osExit = os.Exit
 
-	// Run the application
-	app.Run()
+// exit exits the process with the exit code of err, if any.
+func exit(err error) {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		osExit(exitErr.Code)
+		return
+	}
+	if err != nil {
+		slog.Error("Application run failed", slog.Any("error", err))
+		osExit(EXIT_CODE_FAILURE)
+	}
+}
+
+// ExitError is returned by GobootApplication.Run when the application does not exit with 0, Code is the exit code
+// of the failure analysis, of the exit code generators or of the shutdown signal. Err is the failure, if any.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("application exited with code %v", e.Code)
+	}
+	return fmt.Sprintf("application exited with code %v: %v", e.Code, e.Err)
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
 }
 
 type GobootApplication struct {
 	// Add fields as necessary for your application
-	fxOpts []fx.Option
+	fxOpts    []fx.Option
+	arguments *environment.ApplicationArguments
+	publisher *event.SimpleApplicationEventPublisher
+	runners   *runner.Runners
+	// availability and phasedShutdown drive the graceful shutdown, see shutdown.go
+	availability   *availability.ApplicationAvailability
+	phasedShutdown *lifecycle.PhasedShutdown
+	// mode overrides application.mode when it is not empty
+	mode string
+	// bannerFS holds the banner.txt used when there is none in application.banner-location
+	bannerFS fs.FS
+	// buildInfo describes the binary, it is logged at startup and served by the info actuator
+	buildInfo *buildinfo.BuildInfo
+	// timeline records the startup steps, it is printed at debug level and served by the startup actuator
+	timeline *startup.Timeline
+	// beans holds the provided values and their dependencies, it is served by the beans actuator
+	beans *beans.Registry
+	// origins are the property sources of the properties of the environment, they are served by the env actuator
+	origins *environment.PropertyOrigins
+	// defaultProperties, propertySources, profiles and logger are set with the ApplicationBuilder, see builder.go
+	defaultProperties map[string]any
+	propertySources   []environment.PropertySource
+	profiles          []string
+	logger            *slog.Logger
+	// err holds the invalid settings of the ApplicationBuilder, Run and Start return it
+	err error
 }
 
 func NewGobootApplication(fxOpts ...fx.Option) (*GobootApplication, error) {
 	return &GobootApplication{
-		fxOpts: fxOpts,
+		fxOpts:    fxOpts,
+		arguments: environment.ParseArguments(os.Args[1:]),
+		buildInfo: buildinfo.Read(),
 	}, nil
 }
 
-func (app *GobootApplication) Run() {
+// Run runs the application until it receives a shutdown signal (or until the runners are done in batch mode), it
+// returns an *ExitError when the application fails or exits with another code than 0. Err tells if the application
+// was built with invalid settings.
+func (app *GobootApplication) Run() error {
+	if app.err != nil {
+		return app.err
+	}
 	start := time.Now()
 	log.MDC.Set(log.GO_ROUTINE_NAME_FIELD_NAME, "main")
-	environment := app.prepareEnvironment()
-	app.printBanner(environment)
+	environment, profiles, err := app.prepareEnvironment()
+	if err != nil {
+		return &ExitError{Code: app.reportFailure(environment, err), Err: err}
+	}
+	exitCode, err := app.runApplication(app.newFxApplication(environment, profiles, start), environment, start)
+	if exitCode != 0 || err != nil {
+		return &ExitError{Code: exitCode, Err: err}
+	}
+	return nil
+}
+
+// Err returns the error of the invalid settings the application was built with, see ApplicationBuilder.
+func (app *GobootApplication) Err() error {
+	return app.err
+}
+
+// SetArguments replaces the command-line arguments of the application (os.Args by default),
+// their option arguments are the properties with the highest precedence.
+func (app *GobootApplication) SetArguments(args ...string) {
+	app.arguments = environment.ParseArguments(args)
+}
+
+// newFxApplication sets up the logger, prints the banner and creates the fx application of the environment,
+// with the goboot modules, the application options and the auto-configurations whose conditions match.
+func (app *GobootApplication) newFxApplication(environment *viper.Viper, profiles environment.Profiles, start time.Time) *fx.App {
 	app.setupLogger(environment)
+	app.printBanner(environment, profiles)
 	wd, _ := os.Getwd()
 	logger := slog.With()
-	logger.Info(fmt.Sprintf("Starting Bootstrap using %v with PID %v (%v)", runtime.Version(), os.Getpid(), wd))
-	environmentModule := app.createEnvironmentModule(environment)
+	logger.Info(fmt.Sprintf("Starting %v using %v with PID %v (%v)", app.applicationDescription(environment), runtime.Version(), os.Getpid(), wd))
+	if environment.IsSet(goboot_profiles_active_property_name) {
+		logger.Info(fmt.Sprintf("The following %v profile(s) are active: %v", len(profiles), profiles))
+	} else {
+		logger.Info(fmt.Sprintf("No active profile set, falling back to %v default profile(s): %v", len(profiles), profiles))
+	}
+	app.timeline = startup.NewTimeline(start)
+	app.beans = beans.NewRegistry()
+	environmentModule := app.createEnvironmentModule(environment, profiles)
 	options := []fx.Option{
 		fx.WithLogger(func() fxevent.Logger {
-			return &goboot_fx.SlogLogger{Logger: logger}
+			return &goboot_fx.SlogLogger{Logger: logger, DebugModules: []string{"env"}, Timeline: app.timeline, Beans: app.beans}
 		}),
 		// fx.WithLogger(func() fxevent.Logger {
 		// 	return &fxevent.NopLogger
 		// }),
 	}
-	options = append(options, environmentModule)
-	options = append(options, app.fxOpts...)
+	baseOptions := []fx.Option{app.createLifecycleModule(environment), app.createEventsModule(), app.createRunnersModule(), environmentModule, app.createConfigWatcherModule(environment)}
+	options = append(options, baseOptions...)
+	conditionalOptions, report := app.evaluateConditions(environment, profiles, baseOptions, app.fxOpts, autoconfigure.AutoConfigurations())
+	logger.Debug(report.String())
+	options = append(options, conditionalOptions...)
 	options = append(options, fx.Invoke(func() {
 		slog.Info(fmt.Sprintf("Completed initialization in %v", time.Since(start)))
 	}))
-	fx.New(options...).Run()
+	return fx.New(options...)
 }
 
-func (app *GobootApplication) prepareEnvironment() *viper.Viper {
-	v := viper.New()
-	v.AutomaticEnv()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // this is useful e.g. want to use . in Get() calls, but environmental variables to use _ delimiters (e.g. app.port -> APP_PORT)
-	v.SetConfigType("yaml")
+// runApplication starts the fx application, waits for a shutdown signal and stops it, publishing the lifecycle events.
+// In batch mode (application.mode: batch) the application is stopped once the runners are done instead.
+// Startup errors are explained by the failure analyzers and turned into an exit code, they are returned with it.
+func (app *GobootApplication) runApplication(fxApp *fx.App, v *viper.Viper, start time.Time) (int, error) {
+	if exitCode, err := app.startApplication(fxApp, v, start); err != nil {
+		return exitCode, err
+	}
+	if strings.EqualFold(v.GetString(application_mode_property_name), application_mode_batch) {
+		app.publishEvent(&event.ContextClosing{})
+		if err := app.stopApplication(fxApp); err != nil {
+			return EXIT_CODE_FAILURE, err
+		}
+		return app.runners.ExitCode(), nil
+	}
+	signal := <-fxApp.Wait()
+	app.publishEvent(&event.ContextClosing{Signal: fmt.Sprint(signal.Signal)})
+	app.preStop(v)
+	if err := app.stopApplication(fxApp); err != nil {
+		return EXIT_CODE_FAILURE, err
+	}
+	return signal.ExitCode, nil
+}
+
+// startApplication starts the fx application and calls the runners, the application is ready when it returns
+// without error. On error the application is stopped and the exit code is the one of the failure analysis.
+func (app *GobootApplication) startApplication(fxApp *fx.App, v *viper.Viper, start time.Time) (int, error) {
+	if err := fxApp.Err(); err != nil {
+		app.publishEvent(&event.ApplicationFailed{Err: err})
+		return app.reportFailure(v, err), err
+	}
+	startCtx, cancelStart := context.WithTimeout(context.Background(), fxApp.StartTimeout())
+	defer cancelStart()
+	if err := fxApp.Start(startCtx); err != nil {
+		app.publishEvent(&event.ApplicationFailed{Err: err})
+		return app.reportFailure(v, err), err
+	}
+	app.publishEvent(&event.ApplicationStarted{TimeTaken: time.Since(start)})
+	if app.runners != nil {
+		if err := app.runners.Run(app.arguments); err != nil {
+			app.publishEvent(&event.ApplicationFailed{Err: err})
+			exitCode := app.reportFailure(v, err)
+			app.stopApplication(fxApp)
+			return exitCode, err
+		}
+	}
+	app.publishEvent(&event.ApplicationReady{TimeTaken: time.Since(start)})
+	if app.availability != nil {
+		app.availability.SetReadinessState(availability.ACCEPTING_TRAFFIC)
+	}
+	if app.timeline != nil {
+		app.timeline.Ready(time.Now())
+		slog.Debug(app.timeline.String())
+	}
+	return 0, nil
+}
+
+// publishEvent publishes a lifecycle event, events are dropped when the application failed before the publisher was built.
+func (app *GobootApplication) publishEvent(e any) {
+	if app.publisher != nil {
+		app.publisher.PublishEvent(e)
+	}
+}
+
+func (app *GobootApplication) reportFailure(v *viper.Viper, err error) int {
+	slog.Error("Application run failed", slog.Any("error", err))
+	analysis := analyzeFailure(newFailureAnalyzers(v), err)
+	if analysis == nil {
+		return EXIT_CODE_FAILURE
+	}
+	printFailureAnalysis(os.Stderr, analysis)
+	return analysis.ExitCode
+}
+
+// prepareEnvironment reads the properties from the embedded defaults, the module defaults, the default properties, the config files and
+// config trees, the property sources, the .env file, the environment variables and the command-line arguments,
+// in increasing precedence, and records the origin of each one in app.origins.
+func (app *GobootApplication) prepareEnvironment() (*viper.Viper, environment.Profiles, error) {
+	if app.origins == nil {
+		app.origins = environment.NewPropertyOrigins()
+	}
+	origins := environment.NewPropertyOrigins()
+	v := newEnvironment()
 	data, errData := resources.ReadFile("default.yaml")
 	if errData == nil {
 		errMerge := v.MergeConfig(bytes.NewReader(data))
 		if errMerge != nil {
-			slog.Warn("Embed default.yaml was not successfully read, %s", errMerge)
+			slog.Warn(fmt.Sprintf("Embed default.yaml was not successfully read, %s", errMerge))
 		}
+		origins.RecordAll(v.AllSettings(), environment.ORIGIN_DEFAULTS)
 	} else {
 		slog.Warn("Embed default.yaml was not found")
 	}
-	_, errCfgFile := os.Stat("./application.yaml")
-	if errCfgFile == nil {
-		v.SetConfigFile("./application.yaml")
-		errMerge := v.MergeInConfig()
-		if errMerge != nil {
-			slog.Warn("application.yaml was not successfully read, %s", errMerge)
+	app.mergeModuleDefaults(v, origins, autoconfigure.Defaults())
+	if len(app.defaultProperties) > 0 {
+		defaultSettings := environment.PropertySettings(app.defaultProperties)
+		if err := v.MergeConfigMap(defaultSettings); err != nil {
+			return v, nil, fmt.Errorf("default properties were not successfully merged, %w", err)
+		}
+		origins.RecordAll(defaultSettings, environment.ORIGIN_DEFAULT_PROPERTIES)
+	}
+	// command-line arguments are set first, so they can choose the config locations and the profiles
+	commandLineProperties := app.arguments.Properties()
+	for key, value := range commandLineProperties {
+		v.Set(key, value)
+	}
+	dotenvFileName, dotenv, err := app.readDotenv(v)
+	if err != nil {
+		return v, nil, err
+	}
+	app.applyBootstrapDotenv(v, dotenv, commandLineProperties)
+	locations, err := app.configLocations(v)
+	if err != nil {
+		return v, nil, err
+	}
+	fileNames := app.configFileNames(locations, nil)
+	for _, fileName := range fileNames {
+		if err := app.mergeConfigFile(v, origins, fileName, nil); err != nil {
+			return v, nil, err
+		}
+	}
+	profiles := app.activeProfiles(v)
+	for _, fileName := range app.configFileNames(locations, profiles)[len(fileNames):] {
+		if err := app.mergeConfigFile(v, origins, fileName, nil); err != nil {
+			return v, profiles, err
+		}
+	}
+	for _, source := range app.propertySources {
+		if err := app.mergePropertySource(v, origins, source); err != nil {
+			return v, profiles, err
 		}
-	} else {
-		slog.Debug("application.yaml was not found")
 	}
+	settings := v.AllSettings()
+	// the version of the binary is the default application.version, environment variables can still override it
+	if !v.IsSet(application_version_property_name) && app.buildInfo != nil && app.buildInfo.Version != "" {
+		environment.SetProperty(settings, application_version_property_name, app.buildInfo.Version)
+		origins.Record(application_version_property_name, environment.ORIGIN_BUILD_INFO)
+	}
+	// the .env variables are below the real environment variables, which are applied after them
+	knownKeys := metadataKeys()
+	dotenvKeys := environment.ApplyEnvironmentVariables(settings, dotenv, knownKeys...)
+	for _, key := range dotenvKeys {
+		origins.Record(key, fmt.Sprintf(environment.ORIGIN_DOTENV, environment.CanonicalEnvName(key), dotenvFileName))
+	}
+	environmentKeys := environment.ApplyEnvironmentVariables(settings, os.Environ(), knownKeys...)
+	for _, key := range environmentKeys {
+		origins.Record(key, fmt.Sprintf(environment.ORIGIN_ENVIRONMENT_VARIABLE, environment.CanonicalEnvName(key)))
+	}
+	environmentKeys = append(dotenvKeys, environmentKeys...)
+	for key, value := range commandLineProperties {
+		environment.SetProperty(settings, key, value)
+		origins.Record(key, fmt.Sprintf(environment.ORIGIN_COMMAND_LINE_ARGUMENT, key))
+	}
+	if app.mode != "" {
+		environment.SetProperty(settings, application_mode_property_name, app.mode)
+		origins.Record(application_mode_property_name, environment.ORIGIN_APPLICATION)
+	}
+	if len(app.profiles) > 0 {
+		environment.SetProperty(settings, goboot_profiles_active_property_name, strings.Join(profiles, ","))
+		origins.Record(goboot_profiles_active_property_name, environment.ORIGIN_APPLICATION)
+	}
+	// the {cipher} values are decrypted first, so placeholders can refer to them, and the properties which refer
+	// to them are as sensitive as they are
+	decryptedKeys, err := app.decryptProperties(settings)
+	if err != nil {
+		return v, profiles, err
+	}
+	origins.MarkSensitive(goboot_encrypt_key_property_name)
+	unresolved := viper.New()
+	unresolved.MergeConfigMap(settings)
+	resolvedSettings, sensitiveKeys, err := environment.ResolveSensitivePlaceholders(unresolved, append(environmentKeys, decryptedKeys...), decryptedKeys)
+	if err != nil {
+		return v, profiles, err
+	}
+	for _, key := range append(decryptedKeys, sensitiveKeys...) {
+		origins.MarkSensitive(key)
+	}
+	resolved := viper.New()
+	resolved.SetConfigType("yaml")
+	resolved.MergeConfigMap(resolvedSettings)
+	app.origins.Replace(origins)
+	return resolved, profiles, nil
+}
+
+// applyBootstrapDotenv sets the config locations and the profiles of the .env variables into the environment,
+// unless an environment variable or a command-line argument sets them, so the .env file can choose them too.
+func (app *GobootApplication) applyBootstrapDotenv(v *viper.Viper, dotenv []string, commandLineProperties map[string]string) {
+	bootstrapKeys := map[string]string{
+		config_location_env_name:                                            config_location_property_name,
+		environment.CanonicalEnvName(config_location_property_name):         config_location_property_name,
+		environment.CanonicalEnvName(goboot_profiles_active_property_name):  goboot_profiles_active_property_name,
+		environment.CanonicalEnvName(goboot_profiles_default_property_name): goboot_profiles_default_property_name,
+	}
+	for _, item := range dotenv {
+		name, value, _ := strings.Cut(item, "=")
+		key, found := bootstrapKeys[name]
+		if !found {
+			continue
+		}
+		if _, found := commandLineProperties[key]; found {
+			continue
+		}
+		if _, found := os.LookupEnv(name); found {
+			continue
+		}
+		if _, found := os.LookupEnv(environment.CanonicalEnvName(key)); found {
+			continue
+		}
+		v.Set(key, value)
+	}
+}
+
+// newEnvironment returns the viper used while the configuration files are loaded, environment variables
+// are looked up for known keys so they can select the config locations and the active profiles.
+func newEnvironment() *viper.Viper {
+	v := viper.New()
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_")) // this is useful e.g. want to use . in Get() calls, but environmental variables to use _ delimiters (e.g. app.port -> APP_PORT)
+	v.SetConfigType("yaml")
 	return v
 }
 
-func (app *GobootApplication) printBanner(v *viper.Viper) {
-	bannerText := banner_default_text
-	if v.IsSet(application_banner_property_name) {
-		bannerText = v.GetString(application_banner_property_name)
+// activeProfiles returns the profiles of the application followed by the ones listed in goboot.profiles.active
+// (e.g. GOBOOT_PROFILES_ACTIVE=dev,local), or the ones in goboot.profiles.default when none is active.
+func (app *GobootApplication) activeProfiles(v *viper.Viper) environment.Profiles {
+	profiles := environment.ParseProfiles(app.profiles)
+	if v.IsSet(goboot_profiles_active_property_name) {
+		return environment.ParseProfiles(append([]string(profiles), environment.ParseProfiles(v.Get(goboot_profiles_active_property_name))...))
+	}
+	if len(profiles) > 0 {
+		return profiles
 	}
-	ascii := figlet4go.NewAsciiRender()
-	ascii.LoadFont("./")
-	options := figlet4go.NewRenderOptions()
-	options.FontName = "standard"
-	renderStr, _ := ascii.RenderOpts(bannerText, options)
-	fmt.Print(renderStr)
-	fmt.Printf("  :: %v ::        (%v)\n", libraryName, libraryVersion)
-	fmt.Println()
+	if v.IsSet(goboot_profiles_default_property_name) {
+		return environment.ParseProfiles(v.Get(goboot_profiles_default_property_name))
+	}
+	return environment.Profiles{environment.DEFAULT_PROFILE}
 }
 
 func (app *GobootApplication) setupLogger(v *viper.Viper) {
+	if app.logger != nil {
+		slog.SetDefault(app.logger)
+		return
+	}
+	appName := libraryName
+	if v.IsSet(application_name_property_name) {
+		appName = v.GetString(application_name_property_name)
+	}
+	log.SetupRootLogger(appName, app.logLevel(v))
+}
+
+// applicationDescription names the application in the startup log, e.g. "orders v1.4.0 (revision 1a2b3c4, dirty)".
+func (app *GobootApplication) applicationDescription(v *viper.Viper) string {
+	name := "Bootstrap"
+	if v.IsSet(application_name_property_name) {
+		name = v.GetString(application_name_property_name)
+	}
+	if app.buildInfo == nil || app.buildInfo.String() == "" {
+		return name
+	}
+	return name + " " + app.buildInfo.String()
+}
+
+// gobootVersion is the version of the goboot module the binary was built with.
+func (app *GobootApplication) gobootVersion() string {
+	if app.buildInfo != nil && app.buildInfo.GobootVersion != "" {
+		return app.buildInfo.GobootVersion
+	}
+	return libraryVersion
+}
+
+func (app *GobootApplication) logLevel(v *viper.Viper) slog.Level {
 	strLogLevel := "INFO" // default log level
 	if v.IsSet(application_log_property_name) {
 		strLogLevel = v.GetString(application_log_property_name)
 	}
 	var level slog.Level
 	level.UnmarshalText(([]byte)(strLogLevel))
-	appName := libraryName
-	if v.IsSet(application_name_property_name) {
-		appName = v.GetString(application_name_property_name)
-	}
-	log.SetupRootLogger(appName, level)
+	return level
+}
+
+// createEventsModule provides the ApplicationEventPublisher of the listeners in the application-listeners group
+// and publishes the EnvironmentPrepared event. It comes right after the lifecycle module, whose invoke only appends
+// the OnStop hook of the phases, so its invoke runs before the ones of the runners, the environment, the
+// auto-configurations and the application: fx runs the invokes of the modules in the order they are registered.
+func (app *GobootApplication) createEventsModule() fx.Option {
+	return fx.Module("events",
+		fx.Provide(
+			fx.Annotate(
+				event.NewApplicationEventPublisher,
+				fx.ParamTags(`group:"application-listeners"`),
+				fx.As(new(event.ApplicationEventPublisher)),
+				fx.As(fx.Self()),
+			),
+		),
+		fx.Populate(&app.publisher),
+		fx.Invoke(func(publisher event.ApplicationEventPublisher, v *viper.Viper, profiles environment.Profiles) {
+			publisher.PublishEvent(&event.EnvironmentPrepared{Environment: v, Profiles: profiles})
+		}),
+	)
+}
+
+// createRunnersModule provides the runners of the command-line-runners and application-runners groups,
+// they run after every OnStart hook, and the exit-code-generators which give the exit code in batch mode.
+func (app *GobootApplication) createRunnersModule() fx.Option {
+	return fx.Module("runners",
+		fx.Provide(
+			fx.Annotate(
+				runner.NewRunners,
+				fx.ParamTags(`group:"command-line-runners"`, `group:"application-runners"`, `group:"exit-code-generators"`),
+			),
+		),
+		fx.Populate(&app.runners),
+	)
 }
 
-func (app *GobootApplication) createEnvironmentModule(v *viper.Viper) fx.Option {
+func (app *GobootApplication) createEnvironmentModule(v *viper.Viper, profiles environment.Profiles) fx.Option {
 	annotations := app.createAnnotationsFromEnvironment(v)
+	live := environment.NewLiveEnvironment(v)
 	return fx.Module("env",
 		fx.Provide(
 			context.Background,
+			func() *environment.LiveEnvironment { return live },
+			func() environment.Profiles { return profiles },
+			func() *environment.ApplicationArguments { return app.arguments },
+			func() *buildinfo.BuildInfo { return app.buildInfo },
+			func() *startup.Timeline { return app.timeline },
+			func() *beans.Registry { return app.beans },
+			func() *environment.PropertyOrigins { return app.origins },
 		),
 		fx.Provide(annotations...),
+		fx.Invoke(func(graph fx.DotGraph) {
+			if app.beans != nil {
+				app.beans.SetGraph(string(graph))
+			}
+		}),
 	)
 }
 
+// createAnnotationsFromEnvironment provides every property as named values, one for each type the value can be
+// converted to (see environment.TypedValues), e.g. `name:"server.port"` is available as an int, an int64, a string...
+// Map properties whose children are plain values are also available as map[string]string.
 func (app *GobootApplication) createAnnotationsFromEnvironment(v *viper.Viper) []interface{} {
 	annotations := make([]interface{}, 0)
 	keys := v.AllKeys()
+	mapKeys := []string{}
 	for _, propertyName := range keys {
-		propertyValue := v.Get(propertyName)
-		//fmt.Printf("App Name: %s => %s\n", propertyName, propertyValue)
-		propertyType := reflect.ValueOf(propertyValue)
-		if propertyType.Kind() == reflect.String {
-			durationValue, err := time.ParseDuration(propertyValue.(string))
-			var annotation interface{}
-			if err == nil {
-				annotation = fx.Annotate(func() time.Duration { return durationValue }, fx.ResultTags(`name:"`+propertyName+`"`))
-			} else {
-				var stringValue string = propertyValue.(string)
-				annotation = fx.Annotate(func() string { return stringValue }, fx.ResultTags(`name:"`+propertyName+`"`))
-			}
-			annotations = append(annotations, annotation)
-		} else if propertyType.Kind() == reflect.Int {
-			var intValue = propertyValue.(int)
-			annotation := fx.Annotate(func() int { return intValue }, fx.ResultTags(`name:"`+propertyName+`"`))
-			annotations = append(annotations, annotation)
-		} else if propertyType.Kind() == reflect.Bool {
-			var boolValue = propertyValue.(bool)
-			annotation := fx.Annotate(func() bool { return boolValue }, fx.ResultTags(`name:"`+propertyName+`"`))
+		annotation := namedValuesConstructor(propertyName, environment.TypedValues(v.Get(propertyName)))
+		if annotation != nil {
 			annotations = append(annotations, annotation)
 		}
+		for i := strings.LastIndex(propertyName, "."); i > 0; i = strings.LastIndex(propertyName[:i], ".") {
+			if !slices.Contains(mapKeys, propertyName[:i]) {
+				mapKeys = append(mapKeys, propertyName[:i])
+			}
+		}
+	}
+	for _, mapKey := range mapKeys {
+		if mapValue, ok := environment.StringMapValue(v.Get(mapKey)); ok {
+			annotations = append(annotations, namedValuesConstructor(mapKey, []any{mapValue}))
+		}
 	}
 	annotation := fx.Annotate(func() *viper.Viper { return v })
 	annotations = append(annotations, annotation)
 	return annotations
 }
+
+// namedValuesConstructor returns a constructor with one result per value, all of them named propertyName.
+func namedValuesConstructor(propertyName string, values []any) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	resultTypes := make([]reflect.Type, len(values))
+	results := make([]reflect.Value, len(values))
+	resultTags := make([]string, len(values))
+	for i, value := range values {
+		resultTypes[i] = reflect.TypeOf(value)
+		results[i] = reflect.ValueOf(value)
+		resultTags[i] = `name:"` + propertyName + `"`
+	}
+	constructor := reflect.MakeFunc(reflect.FuncOf(nil, resultTypes, false), func([]reflect.Value) []reflect.Value {
+		return results
+	})
+	return fx.Annotate(constructor.Interface(), fx.ResultTags(resultTags...))
+}
@@ -0,0 +1,320 @@
+package goboot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/environment"
+	"github.com/sjexpos/goboot/event"
+	"github.com/sjexpos/goboot/runner"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+func writeConfigFile(t *testing.T, name string, content string) {
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatalf("Cannot write %v: %v", name, err)
+	}
+}
+
+func TestProfileOverlays(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GOBOOT_PROFILES_ACTIVE", "dev, local")
+	writeConfigFile(t, "application.yaml", "server:\n  port: 8080\napplication:\n  name: base\n  log: Debug\n")
+	writeConfigFile(t, "application-dev.yaml", "server:\n  port: 8081\napplication:\n  name: dev\n")
+	writeConfigFile(t, "application-local.yaml", "server:\n  port: 8082\n")
+
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+
+	if !slices.Equal(profiles, []string{"dev", "local"}) {
+		t.Fatalf("Unexpected active profiles: %v", profiles)
+	}
+	if port := v.GetInt("server.port"); port != 8082 {
+		t.Errorf("server.port should come from the last profile, got %v", port)
+	}
+	if name := v.GetString("application.name"); name != "dev" {
+		t.Errorf("application.name should come from the dev profile, got %v", name)
+	}
+	if level := v.GetString("application.log"); level != "Debug" {
+		t.Errorf("application.log should come from application.yaml, got %v", level)
+	}
+}
+
+func TestDefaultProfile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, "application-default.yaml", "server:\n  port: 9090\n")
+
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+
+	if !slices.Equal(profiles, []string{"default"}) {
+		t.Fatalf("Unexpected default profiles: %v", profiles)
+	}
+	if port := v.GetInt("server.port"); port != 9090 {
+		t.Errorf("server.port should come from application-default.yaml, got %v", port)
+	}
+}
+
+func TestCommandLineAndConfigLocations(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("SERVER_PORT", "7070")
+	if err := os.MkdirAll("etc/app", 0o755); err != nil {
+		t.Fatalf("Cannot create config directory: %v", err)
+	}
+	writeConfigFile(t, "application.yaml", "application:\n  name: ignored\n")
+	writeConfigFile(t, "etc/app/application.yaml", "application:\n  name: external\nconfig:\n  import: optional:file:./missing.yaml, secrets.yaml\n")
+	writeConfigFile(t, "etc/app/secrets.yaml", "datasource:\n  password: secret\n")
+	writeConfigFile(t, "override.yaml", "datasource:\n  username: admin\n")
+	writeConfigFile(t, "override-dev.yaml", "datasource:\n  username: developer\n")
+
+	app, _ := NewGobootApplication()
+	app.arguments = environment.ParseArguments([]string{"--server.port=8081", "--config.location=etc/app/,override.yaml", "--goboot.profiles.active=dev", "import"})
+	v, _, err := app.prepareEnvironment()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if port := v.GetInt("server.port"); port != 8081 {
+		t.Errorf("Command-line arguments should win over environment variables, server.port is %v", port)
+	}
+	if name := v.GetString("application.name"); name != "external" {
+		t.Errorf("application.name should come from the config location, got %v", name)
+	}
+	if password := v.GetString("datasource.password"); password != "secret" {
+		t.Errorf("datasource.password should be imported, got %v", password)
+	}
+	if username := v.GetString("datasource.username"); username != "developer" {
+		t.Errorf("datasource.username should come from the profile file of the location, got %v", username)
+	}
+	if args := app.arguments.NonOptionArgs; !slices.Equal(args, []string{"import"}) {
+		t.Errorf("Unexpected non option arguments: %v", args)
+	}
+}
+
+func TestPropertyOrigins(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("DATASOURCE_PORT", "5433")
+	writeConfigFile(t, "application.yaml", "application:\n  name: origins\nconfig:\n  import: secrets.yaml\n")
+	writeConfigFile(t, "secrets.yaml", "datasource:\n  password: secret\n")
+
+	app, _ := NewGobootApplication()
+	app.SetArguments("--server.port=8081")
+	if _, _, err := app.prepareEnvironment(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"application.name":    "config file application.yaml",
+		"datasource.password": "config file secrets.yaml",
+		"datasource.port":     "environment variable DATASOURCE_PORT",
+		"server.port":         "command-line argument --server.port",
+		"application.mode":    environment.ORIGIN_DEFAULTS,
+	}
+	for key, origin := range expected {
+		if got := app.origins.Origin(key); got != origin {
+			t.Errorf("Origin of %v should be %q, got %q", key, origin, got)
+		}
+	}
+}
+
+func TestConfigTreeAndDotenv(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll("secrets/datasource", 0o755); err != nil {
+		t.Fatalf("Cannot create config tree: %v", err)
+	}
+	writeConfigFile(t, "secrets/datasource/password", "from-tree\n")
+	writeConfigFile(t, "application.yaml", "datasource:\n  password: from-file\n  username: app\nconfig:\n  import: optional:configtree:secrets/,optional:configtree:missing/\n")
+	writeConfigFile(t, "application-local.yaml", "application:\n  name: local\n")
+	writeConfigFile(t, ".env", "GOBOOT_PROFILES_ACTIVE=local\nDATASOURCE_USERNAME=from-dotenv\nDATASOURCE_PORT=5432\n")
+	t.Setenv("DATASOURCE_PORT", "5433")
+
+	app, _ := NewGobootApplication()
+	v, profiles, err := app.prepareEnvironment()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(profiles, environment.Profiles{"local"}) || v.GetString("application.name") != "local" {
+		t.Errorf("The .env file should activate the local profile, got %v", profiles)
+	}
+	expected := map[string][2]string{
+		"datasource.password": {"from-tree", fmt.Sprintf(environment.ORIGIN_CONFIG_TREE, filepath.Join("secrets", "datasource", "password"))},
+		"datasource.username": {"from-dotenv", fmt.Sprintf(environment.ORIGIN_DOTENV, "DATASOURCE_USERNAME", ".env")},
+		"datasource.port":     {"5433", "environment variable DATASOURCE_PORT"},
+	}
+	for key, value := range expected {
+		if v.GetString(key) != value[0] || app.origins.Origin(key) != value[1] {
+			t.Errorf("%v should be %q from %q, got %q from %q", key, value[0], value[1], v.GetString(key), app.origins.Origin(key))
+		}
+	}
+}
+
+func TestModuleDefaults(t *testing.T) {
+	v := newEnvironment()
+	v.MergeConfigMap(map[string]any{"server": map[string]any{"port": 4242}})
+	origins := environment.NewPropertyOrigins()
+	defaults := []*autoconfigure.ModuleDefaults{
+		{Name: "orders", Order: autoconfigure.GORM_ORDER, Data: []byte("orders:\n  page-size: 20\nserver:\n  port: 8080\n")},
+		{Name: "broken", Order: autoconfigure.GORM_ORDER, Data: []byte("orders: [")},
+		{Name: "cache", Order: autoconfigure.MANAGEMENT_ORDER, Data: []byte("orders:\n  page-size: 50\ncache:\n  ttl: 1m\n")},
+	}
+
+	app, _ := NewGobootApplication()
+	app.mergeModuleDefaults(v, origins, defaults)
+
+	expected := map[string][2]string{
+		"server.port":      {"8080", fmt.Sprintf(environment.ORIGIN_MODULE_DEFAULTS, "orders")},
+		"orders.page-size": {"50", fmt.Sprintf(environment.ORIGIN_MODULE_DEFAULTS, "cache")},
+		"cache.ttl":        {"1m", fmt.Sprintf(environment.ORIGIN_MODULE_DEFAULTS, "cache")},
+	}
+	for key, value := range expected {
+		if v.GetString(key) != value[0] || origins.Origin(key) != value[1] {
+			t.Errorf("%v should be %q from %q, got %q from %q", key, value[0], value[1], v.GetString(key), origins.Origin(key))
+		}
+	}
+}
+
+func TestMissingConfigImport(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, "application.yaml", "config:\n  import: file:./missing.yaml\n")
+
+	app, _ := NewGobootApplication()
+	_, _, err := app.prepareEnvironment()
+	if _, ok := err.(*configDataNotFoundError); !ok {
+		t.Fatalf("A config data not found error was expected, got %v", err)
+	}
+}
+
+type typedProperties struct {
+	fx.In
+
+	Port        int                  `name:"server.port"`
+	PortText    string               `name:"server.port"`
+	Ratio       float64              `name:"app.ratio"`
+	MaxSize     environment.DataSize `name:"app.max-size"`
+	Label       string               `name:"app.label"`
+	LabelAsTime time.Duration        `name:"app.label"`
+	Hosts       []string             `name:"app.hosts"`
+	Ids         []int                `name:"app.ids"`
+	Labels      map[string]string    `name:"app.labels"`
+	Release     time.Time            `name:"app.release"`
+}
+
+func TestTypedNamedValues(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("SERVER_PORT", "8081")
+	writeConfigFile(t, "application.yaml", `server:
+  port: 4242
+app:
+  ratio: 0.75
+  max-size: 10MB
+  label: 1h
+  hosts: [a, b]
+  ids: [1, 2]
+  labels:
+    team: core
+    tier: 1
+  release: 2025-01-02T10:00:00Z
+`)
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+
+	var properties typedProperties
+	fxApp := fx.New(fx.NopLogger, app.createEnvironmentModule(v, profiles), fx.Invoke(func(p typedProperties) { properties = p }))
+	if err := fxApp.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if properties.Port != 8081 || properties.PortText != "8081" || properties.Ratio != 0.75 {
+		t.Errorf("Unexpected numbers: %+v", properties)
+	}
+	if properties.MaxSize != 10*environment.MEGABYTES || properties.Label != "1h" || properties.LabelAsTime != time.Hour {
+		t.Errorf("Unexpected sizes or labels: %+v", properties)
+	}
+	if !slices.Equal(properties.Hosts, []string{"a", "b"}) || !slices.Equal(properties.Ids, []int{1, 2}) {
+		t.Errorf("Unexpected lists: %+v", properties)
+	}
+	if properties.Labels["team"] != "core" || properties.Labels["tier"] != "1" || properties.Release.Year() != 2025 {
+		t.Errorf("Unexpected map or time: %+v", properties)
+	}
+}
+
+func TestEnvironmentPreparedEvent(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, "application.yaml", "application:\n  name: events\n")
+
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+	var prepared *event.EnvironmentPrepared
+	fxApp := fx.New(
+		fx.NopLogger,
+		app.createEventsModule(),
+		app.createEnvironmentModule(v, profiles),
+		fx.Provide(fx.Annotate(
+			func() event.ApplicationListener {
+				return event.Listener(func(e *event.EnvironmentPrepared) { prepared = e })
+			},
+			fx.ResultTags(`group:"application-listeners"`),
+		)),
+	)
+
+	if err := fxApp.Err(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if prepared == nil || prepared.Environment.GetString("application.name") != "events" {
+		t.Errorf("EnvironmentPrepared was not published: %v", prepared)
+	}
+	if app.publisher == nil {
+		t.Errorf("The publisher was not populated")
+	}
+}
+
+type importRunner struct {
+	imported *bool
+}
+
+func (r *importRunner) Run(args ...string) error {
+	*r.imported = true
+	return nil
+}
+
+func TestBatchMode(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("APPLICATION_BANNER_MODE", "off")
+	exitCodes := []int{}
+	osExit = func(code int) { exitCodes = append(exitCodes, code) }
+	t.Cleanup(func() { osExit = os.Exit })
+
+	mode, imported, stopped := "", false, false
+	RunOnce(
+		fx.Provide(
+			fx.Annotate(func() runner.CommandLineRunner { return &importRunner{imported: &imported} }, fx.ResultTags(`group:"command-line-runners"`)),
+			fx.Annotate(func() runner.ExitCodeGenerator { return exitCode(0) }, fx.ResultTags(`group:"exit-code-generators"`)),
+			fx.Annotate(func() runner.ExitCodeGenerator { return exitCode(5) }, fx.ResultTags(`group:"exit-code-generators"`)),
+		),
+		fx.Invoke(func(lc fx.Lifecycle, v *viper.Viper) {
+			mode = v.GetString("application.mode")
+			lc.Append(fx.StopHook(func() { stopped = true }))
+		}),
+	)
+
+	if mode != "batch" {
+		t.Errorf("RunOnce should set the batch mode, got %v", mode)
+	}
+	if !imported || !stopped {
+		t.Errorf("The runner should run and the application stop, imported %v stopped %v", imported, stopped)
+	}
+	if !slices.Equal(exitCodes, []int{5}) {
+		t.Errorf("Unexpected exit codes %v", exitCodes)
+	}
+}
+
+type exitCode int
+
+func (c exitCode) GetExitCode() int {
+	return int(c)
+}
@@ -0,0 +1,95 @@
+// Package goboottest starts a goboot application in a test, on random free ports and with property overrides:
+//
+//	var db *gorm.DB
+//	app := goboottest.Start(t, goboottest.WithOptions(usersModule), goboottest.WithSQLite(), goboottest.WithPopulate(&db))
+//	resp, err := app.Client().Get("/users")
+//
+// The application is stopped when the test ends.
+package goboottest
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/sjexpos/goboot"
+)
+
+const serverPortPropertyName = "server.port"
+const managementServerPortPropertyName = "management.server.port"
+
+// Application is an application started by Start.
+type Application struct {
+	*goboot.StartedApplication
+	Port           int
+	ManagementPort int
+}
+
+// Start starts the application with the options and stops it with t.Cleanup, the test fails when it does not start.
+// server.port and management.server.port are random free ports unless they are overridden, and the banner is off.
+func Start(t testing.TB, opts ...Option) *Application {
+	t.Helper()
+	application, err := start(opts...)
+	if err != nil {
+		t.Fatalf("Application did not start: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := application.Stop(); err != nil {
+			t.Errorf("Application did not stop cleanly: %v", err)
+		}
+	})
+	return application
+}
+
+func start(opts ...Option) (*Application, error) {
+	c := &config{properties: map[string]any{"application.banner-mode": "off"}}
+	for _, port := range []string{serverPortPropertyName, managementServerPortPropertyName} {
+		free, err := freePort()
+		if err != nil {
+			return nil, err
+		}
+		c.properties[port] = free
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.sqlite {
+		c.properties["datasource.enabled"] = false
+		c.properties["gorm.enabled"] = false
+		c.fxOpts = append(c.fxOpts, SQLiteModule)
+	}
+	app, err := goboot.NewGobootApplication(c.fxOpts...)
+	if err != nil {
+		return nil, err
+	}
+	app.SetArguments(c.arguments()...)
+	started, err := app.Start()
+	if err != nil {
+		return nil, err
+	}
+	return &Application{
+		StartedApplication: started,
+		Port:               started.Environment.GetInt(serverPortPropertyName),
+		ManagementPort:     started.Environment.GetInt(managementServerPortPropertyName),
+	}, nil
+}
+
+// Client sends requests to the server on server.port.
+func (a *Application) Client() *Client {
+	return newClient(fmt.Sprintf("http://localhost:%v", a.Port))
+}
+
+// ManagementClient sends requests to the management server on management.server.port, e.g. Get("/actuator/info").
+func (a *Application) ManagementClient() *Client {
+	return newClient(fmt.Sprintf("http://localhost:%v", a.ManagementPort))
+}
+
+// freePort returns a TCP port nobody listens on.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,86 @@
+package goboottest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/sjexpos/goboot/startup"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type greeting struct {
+	ID   uint
+	Text string
+}
+
+var helloModule = fx.Module("hello",
+	fx.Provide(fx.Annotate(
+		func(port int, greetingText string) *http.Server {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, greetingText) })
+			return &http.Server{Addr: fmt.Sprintf(":%v", port), Handler: mux}
+		},
+		fx.ParamTags(`name:"server.port"`, `name:"greeting.text"`),
+	)),
+	fx.Invoke(func(lc fx.Lifecycle, server *http.Server) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				ln, err := net.Listen("tcp", server.Addr)
+				if err != nil {
+					return err
+				}
+				go server.Serve(ln)
+				return nil
+			},
+			OnStop: server.Shutdown,
+		})
+	}),
+)
+
+func TestStart(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var server *http.Server
+	var db *gorm.DB
+	var timeline *startup.Timeline
+	app := Start(t,
+		WithOptions(helloModule),
+		WithProperty("greeting.text", "hello from test"),
+		WithSQLite(),
+		WithPopulate(&server, &db, &timeline),
+	)
+
+	if app.Port == 0 || app.Port == 4242 || app.ManagementPort == app.Port {
+		t.Errorf("Unexpected random ports %v and %v", app.Port, app.ManagementPort)
+	}
+	if server == nil || server.Addr != fmt.Sprintf(":%v", app.Port) {
+		t.Errorf("The server was not populated on the random port")
+	}
+	resp, err := app.Client().Get("/hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "hello from test" {
+		t.Errorf("Unexpected response %v %q", resp.StatusCode, body)
+	}
+	if timeline == nil || timeline.TimeTaken() == 0 || len(timeline.Steps()) == 0 {
+		t.Errorf("The startup timeline was not recorded")
+	}
+	if db == nil {
+		t.Fatal("The SQLite gorm was not populated")
+	}
+	if err := db.AutoMigrate(&greeting{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&greeting{Text: "hi"})
+	var count int64
+	if db.Model(&greeting{}).Count(&count); count != 1 {
+		t.Errorf("Expected 1 greeting, got %v", count)
+	}
+}
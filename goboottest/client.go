@@ -0,0 +1,43 @@
+package goboottest
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Client sends requests to a server of the application, the paths are relative to BaseURL.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+func newClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL, HTTPClient: &http.Client{}}
+}
+
+// URL returns the absolute URL of path.
+func (c *Client) URL(path string) string {
+	return c.BaseURL + "/" + strings.TrimPrefix(path, "/")
+}
+
+func (c *Client) Get(path string) (*http.Response, error) {
+	return c.HTTPClient.Get(c.URL(path))
+}
+
+func (c *Client) Post(path string, contentType string, body io.Reader) (*http.Response, error) {
+	return c.HTTPClient.Post(c.URL(path), contentType, body)
+}
+
+// Do sends the request, a relative request URL (e.g. http.NewRequest("PUT", "/users/1", body)) is resolved against BaseURL.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if !req.URL.IsAbs() {
+		absolute, err := req.URL.Parse(c.URL(req.URL.RequestURI()))
+		if err != nil {
+			return nil, err
+		}
+		req.URL = absolute
+		req.Host = absolute.Host
+	}
+	return c.HTTPClient.Do(req)
+}
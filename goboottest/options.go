@@ -0,0 +1,70 @@
+package goboottest
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/fx"
+)
+
+type config struct {
+	properties map[string]any
+	fxOpts     []fx.Option
+	sqlite     bool
+}
+
+// Option customizes the application started by Start.
+type Option func(*config)
+
+// WithProperty overrides a property, it has the precedence of a command-line argument.
+func WithProperty(key string, value any) Option {
+	return func(c *config) {
+		c.properties[key] = value
+	}
+}
+
+// WithProperties overrides the properties, e.g. {"datasource.enabled": false}.
+func WithProperties(properties map[string]any) Option {
+	return func(c *config) {
+		for key, value := range properties {
+			c.properties[key] = value
+		}
+	}
+}
+
+// WithProfiles activates the profiles.
+func WithProfiles(profiles ...string) Option {
+	return WithProperty("goboot.profiles.active", strings.Join(profiles, ","))
+}
+
+// WithConfigLocation reads the configuration files of the locations instead of the ones of the working directory.
+func WithConfigLocation(locations ...string) Option {
+	return WithProperty("config.location", strings.Join(locations, ","))
+}
+
+// WithOptions adds fx options to the application, e.g. its modules or replacements of its beans.
+func WithOptions(fxOpts ...fx.Option) Option {
+	return func(c *config) {
+		c.fxOpts = append(c.fxOpts, fxOpts...)
+	}
+}
+
+// WithPopulate sets the targets to beans of the application like fx.Populate, e.g. var db *gorm.DB; WithPopulate(&db).
+func WithPopulate(targets ...any) Option {
+	return WithOptions(fx.Populate(targets...))
+}
+
+// WithSQLite replaces the datasource and gorm with an in-memory SQLite database, see SQLiteModule.
+func WithSQLite() Option {
+	return func(c *config) {
+		c.sqlite = true
+	}
+}
+
+func (c *config) arguments() []string {
+	args := make([]string, 0, len(c.properties))
+	for key, value := range c.properties {
+		args = append(args, fmt.Sprintf("--%v=%v", key, value))
+	}
+	return args
+}
@@ -0,0 +1,41 @@
+package goboottest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	goboot_gorm "github.com/sjexpos/goboot/gorm"
+	"github.com/sjexpos/goboot/lifecycle"
+	"go.uber.org/fx"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const sqliteDriverName = "sqlite3"
+const sqliteInMemoryDSN = ":memory:"
+
+// SQLiteModule provides an in-memory SQLite *sql.DB and the *gorm.DB on top of it, in place of the datasource and
+// gorm modules which must be disabled (datasource.enabled and gorm.enabled false, as WithSQLite does).
+var SQLiteModule = fx.Module("sqlite",
+	fx.Provide(
+		func(phasedShutdown *lifecycle.PhasedShutdown) (*sql.DB, error) {
+			db, err := sql.Open(sqliteDriverName, sqliteInMemoryDSN)
+			if err != nil {
+				return nil, err
+			}
+			// every connection to :memory: is a new database, the single connection keeps the data
+			db.SetMaxOpenConns(1)
+			phasedShutdown.Register(lifecycle.PHASE_DATASOURCE, "SQLite datasource", func(ctx context.Context) error {
+				return db.Close()
+			})
+			return db, nil
+		},
+		fx.Annotate(
+			func(db *sql.DB, logLevel string, slowThreshold time.Duration) (*gorm.DB, error) {
+				return goboot_gorm.NewORMWithDialector(sqlite.Dialector{DriverName: sqliteDriverName, Conn: db}, logLevel, slowThreshold)
+			},
+			fx.ParamTags(``, `name:"gorm.log.level"`, `name:"gorm.query.slow.threshold"`),
+		),
+	),
+)
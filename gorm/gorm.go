@@ -2,25 +2,52 @@ package gorm
 
 import (
 	"database/sql"
+	_ "embed"
 	"log/slog"
 	"time"
 
+	"github.com/sjexpos/goboot/autoconfigure"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
 
+//go:embed gorm.yaml
+var gormDefaults []byte
+
+// the defaults of gorm.log.level and gorm.query.slow.threshold, which NewORM and NewORMWithDialector expect
+func init() {
+	autoconfigure.RegisterDefaults("gorm", autoconfigure.GORM_ORDER, gormDefaults)
+}
+
 func NewORM(sqlDB *sql.DB, logLevel string, slowThreshold time.Duration) (*gorm.DB, error) {
-	gormDB, errGorm := gorm.Open(postgres.New(postgres.Config{
+	return NewORMWithDialector(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), logLevel, slowThreshold)
+}
+
+// NewLazyORM is NewORM without the ping of the database, which is connected by the first query.
+func NewLazyORM(sqlDB *sql.DB, logLevel string, slowThreshold time.Duration) (*gorm.DB, error) {
+	return newORM(postgres.New(postgres.Config{
 		Conn: sqlDB,
-	}), &gorm.Config{
+	}), logLevel, slowThreshold, true)
+}
+
+// NewORMWithDialector opens gorm with the goboot configuration on another database than PostgreSQL, e.g. SQLite in tests.
+func NewORMWithDialector(dialector gorm.Dialector, logLevel string, slowThreshold time.Duration) (*gorm.DB, error) {
+	return newORM(dialector, logLevel, slowThreshold, false)
+}
+
+func newORM(dialector gorm.Dialector, logLevel string, slowThreshold time.Duration, lazy bool) (*gorm.DB, error) {
+	gormDB, errGorm := gorm.Open(dialector, &gorm.Config{
 		NamingStrategy: schema.NamingStrategy{
 			SingularTable: true,
 		},
 		QueryFields: true,
 		//Logger:      logger.Default.LogMode(logger.Info),
-		Logger:         NewSLog2(logLevel, slowThreshold),
-		TranslateError: true,
+		Logger:               NewSLog2(logLevel, slowThreshold),
+		TranslateError:       true,
+		DisableAutomaticPing: lazy,
 	})
 	if errGorm != nil {
 		return nil, errGorm
@@ -0,0 +1,25 @@
+package gorm
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "gorm.io/driver/sqlite"
+)
+
+func TestNewLazyORM(t *testing.T) {
+	// a closed database fails the ping, as an unreachable one would
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sqlDB.Close()
+
+	if _, err := NewORM(sqlDB, "Silent", time.Second); err == nil {
+		t.Errorf("NewORM should ping the database")
+	}
+	if _, err := NewLazyORM(sqlDB, "Silent", time.Second); err != nil {
+		t.Errorf("NewLazyORM should not ping the database, got %v", err)
+	}
+}
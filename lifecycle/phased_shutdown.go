@@ -0,0 +1,122 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Shutdown phases of the goboot components, higher phases stop first: the HTTP servers stop accepting
+// requests and drain the ones in flight, then the workers stop and the datasource is closed last.
+const (
+	PHASE_WEB_SERVER = 3000
+	PHASE_DEFAULT    = 0
+	PHASE_DATASOURCE = -1000
+)
+
+type stopCallback struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// PhasedShutdown stops the registered components by descending phase when the application stops. The components
+// of a phase stop concurrently and each phase has its own timeout, a phase which times out does not block the next one.
+// goboot stops the phases above PHASE_DEFAULT (the servers) first, then the OnStop hooks which are not registered
+// in a phase run, then the remaining phases.
+type PhasedShutdown struct {
+	mutex           sync.Mutex
+	phases          map[int][]stopCallback
+	stopped         map[int]bool
+	timeoutPerPhase time.Duration
+}
+
+func NewPhasedShutdown(timeoutPerPhase time.Duration) *PhasedShutdown {
+	return &PhasedShutdown{
+		phases:          make(map[int][]stopCallback),
+		stopped:         make(map[int]bool),
+		timeoutPerPhase: timeoutPerPhase,
+	}
+}
+
+// Register adds stop to the phase, name identifies the component in the logs.
+func (p *PhasedShutdown) Register(phase int, name string, stop func(ctx context.Context) error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.phases[phase] = append(p.phases[phase], stopCallback{name: name, stop: stop})
+}
+
+// Timeout is the longest time Stop can take, the timeout per phase times the number of phases.
+func (p *PhasedShutdown) Timeout() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.timeoutPerPhase * time.Duration(len(p.phases))
+}
+
+// Stop stops the phases which were not stopped yet in order, it returns the errors of the components which failed to stop.
+func (p *PhasedShutdown) Stop(ctx context.Context) error {
+	return p.stopPhases(ctx, func(int) bool { return true })
+}
+
+// StopAbove stops the phases higher than phase in order, e.g. the servers with PHASE_DEFAULT, Stop then only stops
+// the other ones.
+func (p *PhasedShutdown) StopAbove(ctx context.Context, phase int) error {
+	return p.stopPhases(ctx, func(candidate int) bool { return candidate > phase })
+}
+
+func (p *PhasedShutdown) stopPhases(ctx context.Context, include func(phase int) bool) error {
+	p.mutex.Lock()
+	phases := []int{}
+	callbacks := make(map[int][]stopCallback)
+	for _, phase := range slices.Backward(slices.Sorted(maps.Keys(p.phases))) {
+		if include(phase) && !p.stopped[phase] {
+			p.stopped[phase] = true
+			phases = append(phases, phase)
+			callbacks[phase] = slices.Clone(p.phases[phase])
+		}
+	}
+	p.mutex.Unlock()
+	errs := []error{}
+	for _, phase := range phases {
+		errs = append(errs, p.stopPhase(ctx, phase, callbacks[phase])...)
+	}
+	return errors.Join(errs...)
+}
+
+func (p *PhasedShutdown) stopPhase(ctx context.Context, phase int, callbacks []stopCallback) []error {
+	slog.Debug(fmt.Sprintf("Stopping components in phase %v", phase))
+	phaseCtx, cancel := context.WithTimeout(ctx, p.timeoutPerPhase)
+	defer cancel()
+	mutex := sync.Mutex{}
+	errs := []error{}
+	wg := sync.WaitGroup{}
+	for _, callback := range callbacks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := callback.stop(phaseCtx); err != nil {
+				mutex.Lock()
+				defer mutex.Unlock()
+				errs = append(errs, fmt.Errorf("%v failed to stop: %w", callback.name, err))
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		mutex.Lock()
+		defer mutex.Unlock()
+		return slices.Clone(errs)
+	case <-phaseCtx.Done():
+		slog.Warn(fmt.Sprintf("Shutdown phase %v ended after %v with components still stopping", phase, p.timeoutPerPhase))
+		return []error{fmt.Errorf("shutdown phase %v did not complete: %w", phase, phaseCtx.Err())}
+	}
+}
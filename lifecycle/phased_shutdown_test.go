@@ -0,0 +1,81 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPhasesStopInOrder(t *testing.T) {
+	mutex := sync.Mutex{}
+	stopped := []string{}
+	stop := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			stopped = append(stopped, name)
+			return nil
+		}
+	}
+	phasedShutdown := NewPhasedShutdown(time.Second)
+	phasedShutdown.Register(PHASE_DATASOURCE, "datasource", stop("datasource"))
+	phasedShutdown.Register(PHASE_DEFAULT, "worker", stop("worker"))
+	phasedShutdown.Register(PHASE_WEB_SERVER, "http", stop("http"))
+
+	if err := phasedShutdown.Stop(context.Background()); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !slices.Equal(stopped, []string{"http", "worker", "datasource"}) {
+		t.Errorf("Unexpected stop order %v", stopped)
+	}
+	if timeout := phasedShutdown.Timeout(); timeout != 3*time.Second {
+		t.Errorf("Unexpected timeout %v", timeout)
+	}
+}
+
+func TestStopAbove(t *testing.T) {
+	stopped := []string{}
+	stop := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			stopped = append(stopped, name)
+			return nil
+		}
+	}
+	phasedShutdown := NewPhasedShutdown(time.Second)
+	phasedShutdown.Register(PHASE_DATASOURCE, "datasource", stop("datasource"))
+	phasedShutdown.Register(PHASE_WEB_SERVER, "http", stop("http"))
+
+	phasedShutdown.StopAbove(context.Background(), PHASE_DEFAULT)
+	stopped = append(stopped, "worker")
+	phasedShutdown.Stop(context.Background())
+
+	if !slices.Equal(stopped, []string{"http", "worker", "datasource"}) {
+		t.Errorf("Unexpected stop order %v", stopped)
+	}
+}
+
+func TestPhaseTimeout(t *testing.T) {
+	phasedShutdown := NewPhasedShutdown(50 * time.Millisecond)
+	phasedShutdown.Register(PHASE_WEB_SERVER, "http", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	closed := false
+	phasedShutdown.Register(PHASE_DATASOURCE, "datasource", func(ctx context.Context) error {
+		closed = ctx.Err() == nil
+		return nil
+	})
+
+	err := phasedShutdown.Stop(context.Background())
+
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "phase 3000 did not complete") {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if !closed {
+		t.Errorf("The datasource phase should have its own timeout")
+	}
+}
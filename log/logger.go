@@ -12,6 +12,14 @@ import (
 	slogzerolog "github.com/samber/slog-zerolog"
 )
 
+// rootLevel is shared by every handler created from the root logger, so the reporting level can be
+// changed after the setup (e.g. when application.log is updated in a live configuration reload).
+var rootLevel = new(slog.LevelVar)
+
+func SetRootLoggerLevel(reportingLevel slog.Level) {
+	rootLevel.Set(reportingLevel)
+}
+
 func SetupRootLogger(appName string, reportingLevel slog.Level) {
 	var logger *slog.Logger
 
@@ -19,8 +27,9 @@ func SetupRootLogger(appName string, reportingLevel slog.Level) {
 	// defer zapL.Sync()
 	// logger = slog.New(zapslog.NewHandler(zapL.Core(), zapslog.WithCaller(true), zapslog.AddStacktraceAt(slog.LevelError)))
 
-	zerologL := createZeroLogLogger(slogLevel2ZerologLevel(reportingLevel))
-	logger = slog.New(NewSlogEnhancedHandler(appName, slogzerolog.Option{Logger: &zerologL}.NewZerologHandler(), "[ %6v]", "[ %12v]"))
+	rootLevel.Set(reportingLevel)
+	zerologL := createZeroLogLogger(zerolog.TraceLevel)
+	logger = slog.New(NewSlogEnhancedHandler(appName, slogzerolog.Option{Level: rootLevel, Logger: &zerologL}.NewZerologHandler(), "[ %6v]", "[ %12v]"))
 
 	slog.SetDefault(logger)
 
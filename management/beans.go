@@ -0,0 +1,26 @@
+package management
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/sjexpos/goboot/beans"
+)
+
+// NewBeansHandler serves the beans actuator: every provided type with its constructor, module, name or group
+// and dependencies.
+func NewBeansHandler(registry *beans.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string][]beans.Bean{"beans": registry.Beans()})
+	})
+}
+
+// NewBeansGraphHandler serves the dependency graph in the DOT format, e.g. curl .../actuator/beans/graph | dot -Tsvg > beans.svg.
+func NewBeansGraphHandler(registry *beans.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		io.WriteString(w, registry.Graph())
+	})
+}
@@ -0,0 +1,114 @@
+package management
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/sjexpos/goboot/environment"
+)
+
+// MASK replaces the values of the secret properties.
+const MASK = "******"
+
+// secretKeyPattern matches the keys whose values are masked, e.g. datasource.password or api.token.
+var secretKeyPattern = regexp.MustCompile(`(?i)(password|secret|token|key)`)
+
+type propertyValue struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Origin string `json:"origin,omitempty"`
+}
+
+type envPayload struct {
+	ActiveProfiles environment.Profiles `json:"activeProfiles"`
+	Properties     []propertyValue      `json:"properties"`
+}
+
+type configPropsBean struct {
+	Prefix     string          `json:"prefix"`
+	Type       string          `json:"type"`
+	Properties []propertyValue `json:"properties"`
+}
+
+// NewEnvHandler serves the env actuator: every property with its effective value and the property source it comes from.
+// The values of the keys matching password, secret, token or key are masked unless showSecrets is true, the decrypted
+// values always are.
+func NewEnvHandler(live *environment.LiveEnvironment, profiles environment.Profiles, origins *environment.PropertyOrigins, showSecrets bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := envPayload{
+			ActiveProfiles: profiles,
+			Properties:     propertyValues(environment.FlattenSettings(live.Get().AllSettings()), origins, showSecrets),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(payload)
+	})
+}
+
+// NewConfigPropsHandler serves the configprops actuator: the configuration properties structs with the value
+// and the origin of each of their properties, secrets are masked like in the env actuator.
+func NewConfigPropsHandler(bound []*environment.BoundProperties, origins *environment.PropertyOrigins, showSecrets bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		beans := []configPropsBean{}
+		for _, properties := range bound {
+			beans = append(beans, configPropsBean{
+				Prefix:     properties.Prefix,
+				Type:       fmt.Sprintf("%T", properties.Value()),
+				Properties: propertyValues(properties.Properties(), origins, showSecrets),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string][]configPropsBean{"beans": beans})
+	})
+}
+
+func propertyValues(properties map[string]any, origins *environment.PropertyOrigins, showSecrets bool) []propertyValue {
+	values := make([]propertyValue, 0, len(properties))
+	for key, value := range properties {
+		// decrypted values are never shown
+		if origins.IsSensitive(key) {
+			value = MASK
+		} else if !showSecrets {
+			value = Sanitize(key, value)
+		}
+		values = append(values, propertyValue{Key: key, Value: value, Origin: origins.Origin(key)})
+	}
+	slices.SortFunc(values, func(a, b propertyValue) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return values
+}
+
+// Sanitize masks value when key is a secret, the values nested in maps and lists are masked by their own key.
+func Sanitize(key string, value any) any {
+	if value == nil {
+		return nil
+	}
+	if secretKeyPattern.MatchString(key) {
+		return MASK
+	}
+	switch typed := value.(type) {
+	case map[string]any:
+		sanitized := make(map[string]any, len(typed))
+		for childKey, child := range typed {
+			sanitized[childKey] = Sanitize(childKey, child)
+		}
+		return sanitized
+	case map[any]any:
+		sanitized := make(map[string]any, len(typed))
+		for childKey, child := range typed {
+			sanitized[fmt.Sprint(childKey)] = Sanitize(fmt.Sprint(childKey), child)
+		}
+		return sanitized
+	case []any:
+		sanitized := make([]any, len(typed))
+		for i, item := range typed {
+			sanitized[i] = Sanitize("", item)
+		}
+		return sanitized
+	}
+	return value
+}
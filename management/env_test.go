@@ -0,0 +1,83 @@
+package management
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/viper"
+)
+
+func TestSanitize(t *testing.T) {
+	cases := map[string]any{
+		"datasource.password": MASK,
+		"api.token":           MASK,
+		"cipher.key-file":     MASK,
+		"client.Secret":       MASK,
+		"datasource.host":     "localhost",
+	}
+	for key, expected := range cases {
+		value := "value"
+		if expected != MASK {
+			value = expected.(string)
+		}
+		if sanitized := Sanitize(key, value); sanitized != expected {
+			t.Errorf("Sanitize(%v) should be %v, got %v", key, expected, sanitized)
+		}
+	}
+	nested := Sanitize("oauth", map[string]any{"client-id": "users", "client-secret": "s3cr3t"}).(map[string]any)
+	if nested["client-id"] != "users" || nested["client-secret"] != MASK {
+		t.Errorf("Nested secrets should be masked, got %v", nested)
+	}
+}
+
+func TestEnvHandler(t *testing.T) {
+	v := viper.New()
+	v.Set("datasource.host", "db")
+	v.Set("datasource.password", "s3cr3t")
+	origins := environment.NewPropertyOrigins()
+	origins.Record("datasource.host", "config file application.yaml")
+	origins.Record("datasource.password", "environment variable DATASOURCE_PASSWORD")
+
+	for _, showSecrets := range []bool{false, true} {
+		recorder := httptest.NewRecorder()
+		NewEnvHandler(environment.NewLiveEnvironment(v), environment.Profiles{"dev"}, origins, showSecrets).ServeHTTP(recorder, httptest.NewRequest("GET", "/actuator/env", nil))
+		payload := envPayload{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &payload); err != nil {
+			t.Fatal(err)
+		}
+		password := MASK
+		if showSecrets {
+			password = "s3cr3t"
+		}
+		expected := []propertyValue{
+			{Key: "datasource.host", Value: "db", Origin: "config file application.yaml"},
+			{Key: "datasource.password", Value: password, Origin: "environment variable DATASOURCE_PASSWORD"},
+		}
+		if len(payload.Properties) != len(expected) {
+			t.Fatalf("Unexpected properties %v", payload.Properties)
+		}
+		for i, property := range payload.Properties {
+			if property != expected[i] {
+				t.Errorf("Expected %v, got %v", expected[i], property)
+			}
+		}
+	}
+}
+
+func TestEnvHandlerMasksDecryptedValues(t *testing.T) {
+	v := viper.New()
+	v.Set("datasource.username", "admin")
+	origins := environment.NewPropertyOrigins()
+	origins.MarkSensitive("datasource.username")
+
+	recorder := httptest.NewRecorder()
+	NewEnvHandler(environment.NewLiveEnvironment(v), environment.Profiles{"default"}, origins, true).ServeHTTP(recorder, httptest.NewRequest("GET", "/actuator/env", nil))
+
+	payload := envPayload{}
+	json.Unmarshal(recorder.Body.Bytes(), &payload)
+	if len(payload.Properties) != 1 || payload.Properties[0].Value != MASK {
+		t.Errorf("Decrypted values should always be masked, got %v", payload.Properties)
+	}
+}
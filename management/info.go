@@ -0,0 +1,40 @@
+package management
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+
+	"github.com/sjexpos/goboot/buildinfo"
+)
+
+type appInfo struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type runtimeInfo struct {
+	Arch    string `json:"arch"`
+	OS      string `json:"os"`
+	Version string `json:"version"`
+}
+
+type info struct {
+	App     appInfo              `json:"app"`
+	Build   *buildinfo.BuildInfo `json:"build"`
+	Runtime runtimeInfo          `json:"runtime"`
+}
+
+// NewInfoHandler serves the info actuator: the application name and version, the build information
+// (module, build time, goboot version, git revision and dirty flag) and the runtime.
+func NewInfoHandler(name string, version string, buildInfo *buildinfo.BuildInfo) http.Handler {
+	payload := info{
+		App:     appInfo{Name: name, Version: version},
+		Build:   buildInfo,
+		Runtime: runtimeInfo{Arch: runtime.GOARCH, OS: runtime.GOOS, Version: runtime.Version()},
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(payload)
+	})
+}
@@ -0,0 +1,47 @@
+package management
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sjexpos/goboot/startup"
+)
+
+type startupStep struct {
+	Name       string   `json:"name"`
+	Kind       string   `json:"kind"`
+	Module     string   `json:"module,omitempty"`
+	Types      []string `json:"types,omitempty"`
+	Duration   string   `json:"duration"`
+	DurationMs float64  `json:"durationMs"`
+}
+
+type startupTimeline struct {
+	StartTime string        `json:"startTime"`
+	TimeTaken string        `json:"timeTaken,omitempty"`
+	Steps     []startupStep `json:"steps"`
+}
+
+// NewStartupHandler serves the startup actuator: the constructors, decorators, invokes and OnStart hooks which ran
+// while the application started, the slowest first.
+func NewStartupHandler(timeline *startup.Timeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := startupTimeline{StartTime: timeline.StartTime().Format(time.RFC3339Nano), Steps: []startupStep{}}
+		if timeTaken := timeline.TimeTaken(); timeTaken > 0 {
+			payload.TimeTaken = timeTaken.String()
+		}
+		for _, step := range timeline.Steps() {
+			payload.Steps = append(payload.Steps, startupStep{
+				Name:       step.Name,
+				Kind:       step.Kind,
+				Module:     step.Module,
+				Types:      step.Types,
+				Duration:   step.Duration.String(),
+				DurationMs: float64(step.Duration) / float64(time.Millisecond),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(payload)
+	})
+}
@@ -0,0 +1,122 @@
+package metadata
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Types of the properties.
+const (
+	TYPE_STRING    = "string"
+	TYPE_INT       = "int"
+	TYPE_FLOAT     = "float"
+	TYPE_BOOL      = "bool"
+	TYPE_DURATION  = "duration"
+	TYPE_DATA_SIZE = "data-size"
+	TYPE_LIST      = "list"
+	// TYPE_MAP is a property whose children are free, e.g. open-api-v3.info
+	TYPE_MAP = "map"
+)
+
+// Deprecation tells a property should not be used anymore, Replacement is the key to use instead.
+type Deprecation struct {
+	Reason      string `json:"reason,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// Property describes a configuration property a module reads.
+type Property struct {
+	Key         string       `json:"key"`
+	Type        string       `json:"type"`
+	Default     string       `json:"default,omitempty"`
+	Description string       `json:"description"`
+	Deprecation *Deprecation `json:"deprecation,omitempty"`
+}
+
+// Registry holds the properties contributed by the modules.
+type Registry struct {
+	mutex      sync.Mutex
+	properties map[string]Property
+}
+
+func NewRegistry() *Registry {
+	return &Registry{properties: make(map[string]Property)}
+}
+
+// Register adds the properties, a property registered again replaces the previous one.
+func (r *Registry) Register(properties ...Property) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, property := range properties {
+		r.properties[strings.ToLower(property.Key)] = property
+	}
+}
+
+// Lookup returns the property of key, keys are case-insensitive like the viper ones.
+func (r *Registry) Lookup(key string) (Property, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	property, found := r.properties[strings.ToLower(key)]
+	return property, found
+}
+
+// Properties returns the properties sorted by key.
+func (r *Registry) Properties() []Property {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	properties := make([]Property, 0, len(r.properties))
+	for _, property := range r.properties {
+		properties = append(properties, property)
+	}
+	slices.SortFunc(properties, func(a, b Property) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return properties
+}
+
+// WriteReference writes the documented reference of the properties, grouped by their first key part.
+func (r *Registry) WriteReference(w io.Writer) {
+	group := ""
+	for _, property := range r.Properties() {
+		prefix, _, _ := strings.Cut(property.Key, ".")
+		if prefix != group {
+			group = prefix
+			fmt.Fprintf(w, "\n# %v\n\n", group)
+		}
+		fmt.Fprintf(w, "%v (%v)", property.Key, property.Type)
+		if property.Default != "" {
+			fmt.Fprintf(w, " = %v", property.Default)
+		}
+		fmt.Fprintf(w, "\n    %v\n", property.Description)
+		if property.Deprecation != nil {
+			fmt.Fprintf(w, "    Deprecated%v\n", property.Deprecation.describe())
+		}
+	}
+}
+
+func (d *Deprecation) describe() string {
+	text := ""
+	if d.Replacement != "" {
+		text += ", use " + d.Replacement + " instead"
+	}
+	if d.Reason != "" {
+		text += ": " + d.Reason
+	}
+	return text
+}
+
+var defaultRegistry = NewRegistry()
+
+// Register adds the properties to the registry of the goboot modules, the modules call it from init.
+func Register(properties ...Property) {
+	defaultRegistry.Register(properties...)
+}
+
+// Default returns the registry of the goboot modules.
+func Default() *Registry {
+	return defaultRegistry
+}
@@ -0,0 +1,115 @@
+package metadata
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/cast"
+)
+
+// Kinds of problems found by Validate.
+const (
+	PROBLEM_UNKNOWN_KEY   = "unknown key"
+	PROBLEM_TYPE_MISMATCH = "type mismatch"
+	PROBLEM_DEPRECATED    = "deprecated key"
+)
+
+// Problem is a property of a configuration which does not match the metadata.
+type Problem struct {
+	Key     string
+	Kind    string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%v: %v, %v", p.Key, p.Kind, p.Message)
+}
+
+// Validate checks the settings of a configuration file (e.g. viper.AllSettings()) against the properties: it reports
+// the keys which are not registered, the values which can not be converted to the type of their property and
+// the deprecated keys. Values with placeholders are not type checked, they are only known once resolved.
+func (r *Registry) Validate(settings map[string]any) []Problem {
+	problems := []Problem{}
+	r.validate(settings, "", &problems)
+	slices.SortStableFunc(problems, func(a, b Problem) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return problems
+}
+
+func (r *Registry) validate(settings map[string]any, prefix string, problems *[]Problem) {
+	for name, value := range settings {
+		key := prefix + name
+		property, found := r.Lookup(key)
+		if !found {
+			if children, isMap := toMap(value); isMap {
+				r.validate(children, key+".", problems)
+			} else {
+				*problems = append(*problems, Problem{Key: key, Kind: PROBLEM_UNKNOWN_KEY, Message: "no module reads it"})
+			}
+			continue
+		}
+		if property.Deprecation != nil {
+			*problems = append(*problems, Problem{Key: key, Kind: PROBLEM_DEPRECATED, Message: "it is deprecated" + property.Deprecation.describe()})
+		}
+		if err := checkType(property.Type, value); err != nil {
+			*problems = append(*problems, Problem{Key: key, Kind: PROBLEM_TYPE_MISMATCH, Message: err.Error()})
+		}
+	}
+}
+
+func toMap(value any) (map[string]any, bool) {
+	switch typed := value.(type) {
+	case map[string]any:
+		return typed, true
+	case map[any]any:
+		return cast.ToStringMap(typed), true
+	}
+	return nil, false
+}
+
+// checkType returns an error when value can not be converted to the type.
+func checkType(propertyType string, value any) error {
+	if text, isString := value.(string); isString && strings.Contains(text, "${") {
+		return nil
+	}
+	if _, isMap := toMap(value); isMap != (propertyType == TYPE_MAP) {
+		return fmt.Errorf("expected %v, got %v", propertyType, describe(value))
+	}
+	var err error
+	switch propertyType {
+	case TYPE_INT:
+		_, err = cast.ToIntE(value)
+	case TYPE_FLOAT:
+		_, err = cast.ToFloat64E(value)
+	case TYPE_BOOL:
+		_, err = cast.ToBoolE(value)
+	case TYPE_DURATION:
+		_, err = cast.ToDurationE(value)
+	case TYPE_DATA_SIZE:
+		_, err = environment.ParseDataSize(cast.ToString(value))
+	case TYPE_LIST:
+		// a list or a comma separated string
+		return nil
+	case TYPE_STRING:
+		if _, isList := value.([]any); isList {
+			err = fmt.Errorf("got a list")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("expected %v, got %v", propertyType, describe(value))
+	}
+	return nil
+}
+
+func describe(value any) string {
+	if _, isMap := toMap(value); isMap {
+		return "a map"
+	}
+	if _, isList := value.([]any); isList {
+		return "a list"
+	}
+	return fmt.Sprintf("'%v'", value)
+}
@@ -0,0 +1,63 @@
+package metadata
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func newTestRegistry() *Registry {
+	registry := NewRegistry()
+	registry.Register(
+		Property{Key: "server.port", Type: TYPE_INT, Default: "4242", Description: "Port of the web server."},
+		Property{Key: "server.timeout", Type: TYPE_DURATION, Description: "Timeout of the requests."},
+		Property{Key: "server.max-size", Type: TYPE_DATA_SIZE, Description: "Maximum size of the requests."},
+		Property{Key: "open-api-v3.info", Type: TYPE_MAP, Description: "Info object."},
+		Property{Key: "open-api-v3.securityRequirement", Type: TYPE_LIST, Description: "Security requirements."},
+		Property{Key: "app.old-name", Type: TYPE_STRING, Description: "Old name.", Deprecation: &Deprecation{Replacement: "application.name"}},
+	)
+	return registry
+}
+
+func TestValidate(t *testing.T) {
+	settings := map[string]any{
+		"server": map[string]any{
+			"port":     "not a number",
+			"timeout":  "30s",
+			"max-size": "10MB",
+			"porrt":    8080,
+		},
+		"open-api-v3": map[string]any{
+			"info":                map[string]any{"title": "Users", "contact": map[string]any{"name": "me"}},
+			"securityrequirement": []any{map[string]any{"oauth2": []any{"read"}}},
+		},
+		"app": map[string]any{"old-name": "${NAME:users}"},
+	}
+
+	problems := newTestRegistry().Validate(settings)
+
+	got := []string{}
+	for _, problem := range problems {
+		got = append(got, problem.Key+" "+problem.Kind)
+	}
+	expected := []string{"app.old-name deprecated key", "server.porrt unknown key", "server.port type mismatch"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected problems %v, got %v", expected, problems)
+	}
+	if !strings.Contains(problems[0].Message, "use application.name instead") {
+		t.Errorf("The replacement should be suggested, got %v", problems[0].Message)
+	}
+}
+
+func TestWriteReference(t *testing.T) {
+	reference := bytes.Buffer{}
+	newTestRegistry().WriteReference(&reference)
+
+	text := reference.String()
+	if !strings.Contains(text, "# server") || !strings.Contains(text, "server.port (int) = 4242\n    Port of the web server.") {
+		t.Errorf("Unexpected reference %v", text)
+	}
+	if !strings.Contains(text, "Deprecated, use application.name instead") {
+		t.Errorf("Deprecated properties should be marked, got %v", text)
+	}
+}
@@ -0,0 +1,39 @@
+package goboot
+
+import "github.com/sjexpos/goboot/metadata"
+
+func init() {
+	metadata.Register(
+		metadata.Property{Key: goboot_profiles_active_property_name, Type: metadata.TYPE_LIST, Description: "Active profiles, comma separated."},
+		metadata.Property{Key: goboot_profiles_default_property_name, Type: metadata.TYPE_LIST, Default: "default", Description: "Profiles used when no profile is active."},
+		metadata.Property{Key: goboot_autoconfigure_exclude_property_name, Type: metadata.TYPE_LIST, Description: "Auto-configurations which are not applied, e.g. management,gorm."},
+		metadata.Property{Key: goboot_encrypt_key_property_name, Type: metadata.TYPE_STRING, Description: "Base64 AES key which decrypts the {cipher} values, usually set with GOBOOT_ENCRYPT_KEY."},
+		metadata.Property{Key: goboot_encrypt_key_file_property_name, Type: metadata.TYPE_STRING, Description: "File of the base64 AES key which decrypts the {cipher} values."},
+		metadata.Property{Key: goboot_lifecycle_timeout_per_shutdown_phase_property_name, Type: metadata.TYPE_DURATION, Default: "30s", Description: "Time each shutdown phase has to complete."},
+		metadata.Property{Key: goboot_lifecycle_pre_stop_delay_property_name, Type: metadata.TYPE_DURATION, Default: "0s", Description: "Time the application refuses traffic before its servers stop."},
+		metadata.Property{Key: goboot_lazy_initialization_property_name, Type: metadata.TYPE_BOOL, Default: "false", Description: "Whether the datasource and gorm wait for the first query to connect to the database."},
+		metadata.Property{Key: config_location_property_name, Type: metadata.TYPE_LIST, Default: config_default_location, Description: "Directories and files the application.yaml files are read from."},
+		metadata.Property{Key: config_import_property_name, Type: metadata.TYPE_LIST, Description: "Additional configuration files, prefixed with optional: when they may not exist, or config trees prefixed with configtree:."},
+		metadata.Property{Key: config_dotenv_location_property_name, Type: metadata.TYPE_STRING, Default: config_dotenv_default_location, Description: "The .env file whose variables are applied before the environment variables, it is ignored when it does not exist."},
+		metadata.Property{Key: config_watch_enabled_property_name, Type: metadata.TYPE_BOOL, Default: "false", Description: "Whether the configuration files are reloaded when they change."},
+		metadata.Property{Key: config_watch_delay_property_name, Type: metadata.TYPE_DURATION, Default: "500ms", Description: "Time the configuration files have to be unchanged before they are reloaded."},
+		metadata.Property{Key: application_name_property_name, Type: metadata.TYPE_STRING, Description: "Name of the application, used in the logs and the info actuator."},
+		metadata.Property{Key: application_version_property_name, Type: metadata.TYPE_STRING, Description: "Version of the application, the version of the binary by default."},
+		metadata.Property{Key: application_mode_property_name, Type: metadata.TYPE_STRING, Default: application_mode_server, Description: "server runs until a shutdown signal, batch stops once the runners are done."},
+		metadata.Property{Key: application_log_property_name, Type: metadata.TYPE_STRING, Default: "Info", Description: "Log level: Debug, Info, Warn or Error."},
+		metadata.Property{Key: application_banner_property_name, Type: metadata.TYPE_STRING, Default: "Go-boot", Description: "Text of the banner when there is no banner.txt."},
+		metadata.Property{Key: application_banner_mode_property_name, Type: metadata.TYPE_STRING, Default: "console", Description: "Where the banner is printed: console, log or off."},
+		metadata.Property{Key: application_banner_location_property_name, Type: metadata.TYPE_STRING, Default: banner_default_location, Description: "File of the banner, it can use ${property:default} placeholders."},
+		metadata.Property{Key: application_banner_font_property_name, Type: metadata.TYPE_STRING, Default: banner_default_font, Description: "Figlet font of the banner text: standard, larry3d or a .flf file."},
+	)
+}
+
+// metadataKeys are the keys of the registered properties, the environment variables of the keys which are not in
+// any config file are mapped to them.
+func metadataKeys() []string {
+	keys := []string{}
+	for _, property := range metadata.Default().Properties() {
+		keys = append(keys, property.Key)
+	}
+	return keys
+}
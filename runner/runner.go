@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/sjexpos/goboot/core"
+	"github.com/sjexpos/goboot/environment"
+)
+
+// CommandLineRunner runs once the application is started, with the raw command-line arguments.
+type CommandLineRunner interface {
+	Run(args ...string) error
+}
+
+// ApplicationRunner runs once the application is started, with the parsed command-line arguments.
+type ApplicationRunner interface {
+	Run(args *environment.ApplicationArguments) error
+}
+
+// ExitCodeGenerator chooses the exit code of the application, it is implemented by the errors of the runners
+// and by the exit code generators which give the exit code of a batch application.
+type ExitCodeGenerator interface {
+	GetExitCode() int
+}
+
+// RunnerError is returned when a runner fails, Runner is the type of the runner.
+type RunnerError struct {
+	Runner string
+	Err    error
+}
+
+func (e *RunnerError) Error() string {
+	return fmt.Sprintf("runner %v failed: %v", e.Runner, e.Err)
+}
+
+func (e *RunnerError) Unwrap() error {
+	return e.Err
+}
+
+// Runners are the command-line and application runners of the application, Run calls them all
+// in core.Ordered order (the ones which do not implement it run last).
+type Runners struct {
+	runners            []any
+	exitCodeGenerators []ExitCodeGenerator
+}
+
+func NewRunners(commandLineRunners []CommandLineRunner, applicationRunners []ApplicationRunner, exitCodeGenerators []ExitCodeGenerator) *Runners {
+	runners := []any{}
+	for _, r := range commandLineRunners {
+		runners = append(runners, r)
+	}
+	for _, r := range applicationRunners {
+		runners = append(runners, r)
+	}
+	return &Runners{runners: core.SortByOrder(runners), exitCodeGenerators: core.SortByOrder(exitCodeGenerators)}
+}
+
+// Run calls the runners in order and stops at the first one which fails.
+func (r *Runners) Run(args *environment.ApplicationArguments) error {
+	for _, item := range r.runners {
+		name := fmt.Sprintf("%T", item)
+		slog.Debug(fmt.Sprintf("Running %v", name))
+		var err error
+		switch typed := item.(type) {
+		case CommandLineRunner:
+			err = typed.Run(args.SourceArgs...)
+		case ApplicationRunner:
+			err = typed.Run(args)
+		}
+		if err != nil {
+			return &RunnerError{Runner: name, Err: err}
+		}
+	}
+	return nil
+}
+
+// ExitCode returns the first exit code other than 0 of the exit code generators, in core.Ordered order,
+// or 0 when there is none.
+func (r *Runners) ExitCode() int {
+	for _, generator := range r.exitCodeGenerators {
+		if exitCode := generator.GetExitCode(); exitCode != 0 {
+			return exitCode
+		}
+	}
+	return 0
+}
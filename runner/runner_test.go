@@ -0,0 +1,97 @@
+package runner
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/sjexpos/goboot/environment"
+)
+
+type recordingRunner struct {
+	name     string
+	order    int
+	err      error
+	executed *[]string
+}
+
+func (r *recordingRunner) Run(args ...string) error {
+	*r.executed = append(*r.executed, r.name+":"+args[0])
+	return r.err
+}
+
+func (r *recordingRunner) GetOrder() int {
+	return r.order
+}
+
+type optionRunner struct {
+	executed *[]string
+}
+
+func (r *optionRunner) Run(args *environment.ApplicationArguments) error {
+	*r.executed = append(*r.executed, "import:"+args.OptionValues("file")[0])
+	return nil
+}
+
+func TestRunnersOrder(t *testing.T) {
+	executed := []string{}
+	runners := NewRunners(
+		[]CommandLineRunner{&recordingRunner{name: "second", order: 2, executed: &executed}, &recordingRunner{name: "first", order: 1, executed: &executed}},
+		[]ApplicationRunner{&optionRunner{executed: &executed}},
+		nil,
+	)
+
+	err := runners.Run(environment.ParseArguments([]string{"--file=data.csv"}))
+
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !slices.Equal(executed, []string{"first:--file=data.csv", "second:--file=data.csv", "import:data.csv"}) {
+		t.Errorf("Unexpected runs %v", executed)
+	}
+}
+
+func TestRunnerFailureStopsTheRunners(t *testing.T) {
+	executed := []string{}
+	cause := errors.New("cache warm up failed")
+	runners := NewRunners(
+		[]CommandLineRunner{&recordingRunner{name: "failing", order: 1, err: cause, executed: &executed}, &recordingRunner{name: "next", order: 2, executed: &executed}},
+		nil,
+		nil,
+	)
+
+	err := runners.Run(environment.ParseArguments([]string{"run"}))
+
+	var runnerErr *RunnerError
+	if !errors.As(err, &runnerErr) || !errors.Is(err, cause) || runnerErr.Runner != "*runner.recordingRunner" {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !slices.Equal(executed, []string{"failing:run"}) {
+		t.Errorf("Unexpected runs %v", executed)
+	}
+}
+
+type exitCode int
+
+func (c exitCode) GetExitCode() int {
+	return int(c)
+}
+
+type orderedExitCode struct {
+	exitCode
+	order int
+}
+
+func (c *orderedExitCode) GetOrder() int {
+	return c.order
+}
+
+func TestExitCode(t *testing.T) {
+	runners := NewRunners(nil, nil, []ExitCodeGenerator{exitCode(0), exitCode(4), &orderedExitCode{exitCode: 3, order: 1}})
+	if code := runners.ExitCode(); code != 3 {
+		t.Errorf("The ordered generator should win, got %v", code)
+	}
+	if code := NewRunners(nil, nil, []ExitCodeGenerator{exitCode(0)}).ExitCode(); code != 0 {
+		t.Errorf("Unexpected exit code %v", code)
+	}
+}
@@ -0,0 +1,79 @@
+package goboot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/sjexpos/goboot/availability"
+	"github.com/sjexpos/goboot/lifecycle"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+const goboot_lifecycle_timeout_per_shutdown_phase_property_name = "goboot.lifecycle.timeout-per-shutdown-phase"
+const goboot_lifecycle_pre_stop_delay_property_name = "goboot.lifecycle.pre-stop-delay"
+const lifecycle_default_timeout_per_shutdown_phase = 30 * time.Second
+
+// createLifecycleModule provides the application availability and the phased shutdown. It is the first module,
+// so the OnStop hook of the phases is the first one appended and it runs after every other OnStop hook: stopApplication
+// stops the servers before, the OnStop hook stops the remaining phases.
+func (app *GobootApplication) createLifecycleModule(v *viper.Viper) fx.Option {
+	timeoutPerPhase := lifecycle_default_timeout_per_shutdown_phase
+	if v.IsSet(goboot_lifecycle_timeout_per_shutdown_phase_property_name) {
+		timeoutPerPhase = v.GetDuration(goboot_lifecycle_timeout_per_shutdown_phase_property_name)
+	}
+	return fx.Module("lifecycle",
+		fx.Provide(
+			availability.NewApplicationAvailability,
+			func() *lifecycle.PhasedShutdown { return lifecycle.NewPhasedShutdown(timeoutPerPhase) },
+		),
+		fx.Populate(&app.availability, &app.phasedShutdown),
+		fx.Invoke(func(lc fx.Lifecycle, phasedShutdown *lifecycle.PhasedShutdown) {
+			lc.Append(fx.StopHook(phasedShutdown.Stop))
+		}),
+	)
+}
+
+// preStop marks the application as not ready and waits goboot.lifecycle.pre-stop-delay, so the load balancers
+// stop sending requests before the servers stop.
+func (app *GobootApplication) preStop(v *viper.Viper) {
+	if app.availability == nil {
+		return
+	}
+	app.availability.SetReadinessState(availability.REFUSING_TRAFFIC)
+	delay := v.GetDuration(goboot_lifecycle_pre_stop_delay_property_name)
+	if delay > 0 {
+		slog.Info(fmt.Sprintf("Readiness is %v, waiting %v before shutdown", availability.STATUS_OUT_OF_SERVICE, delay))
+		time.Sleep(delay)
+	}
+}
+
+// stopTimeout is the fx stop timeout plus the time the shutdown phases can take.
+func (app *GobootApplication) stopTimeout(fxApp *fx.App) time.Duration {
+	if app.phasedShutdown == nil {
+		return fxApp.StopTimeout()
+	}
+	return fxApp.StopTimeout() + app.phasedShutdown.Timeout()
+}
+
+// stopApplication stops the servers so the requests in flight complete first, then runs the OnStop hooks, then the
+// remaining shutdown phases, and waits for the asynchronous event listeners.
+func (app *GobootApplication) stopApplication(fxApp *fx.App) error {
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), app.stopTimeout(fxApp))
+	defer cancelStop()
+	var errServers error
+	if app.phasedShutdown != nil {
+		errServers = app.phasedShutdown.StopAbove(stopCtx, lifecycle.PHASE_DEFAULT)
+	}
+	err := errors.Join(errServers, fxApp.Stop(stopCtx))
+	if app.publisher != nil {
+		app.publisher.Wait()
+	}
+	if err != nil {
+		slog.Error("Application did not stop cleanly", slog.Any("error", err))
+	}
+	return err
+}
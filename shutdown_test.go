@@ -0,0 +1,64 @@
+package goboot
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sjexpos/goboot/availability"
+	"github.com/sjexpos/goboot/event"
+	"github.com/sjexpos/goboot/lifecycle"
+	"go.uber.org/fx"
+)
+
+func TestGracefulShutdown(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeConfigFile(t, "application.yaml", "goboot:\n  lifecycle:\n    pre-stop-delay: 20ms\n")
+
+	app, _ := NewGobootApplication()
+	v, profiles, _ := app.prepareEnvironment()
+	stopped := []string{}
+	var readinessWhileStopping availability.ReadinessState
+	fxApp := fx.New(
+		fx.NopLogger,
+		app.createLifecycleModule(v),
+		app.createEventsModule(),
+		app.createRunnersModule(),
+		app.createEnvironmentModule(v, profiles),
+		fx.Provide(fx.Annotate(
+			func(shutdowner fx.Shutdowner) event.ApplicationListener {
+				return event.Listener(func(*event.ApplicationReady) { shutdowner.Shutdown() })
+			},
+			fx.ResultTags(`group:"application-listeners"`),
+		)),
+		fx.Invoke(func(lc fx.Lifecycle, phasedShutdown *lifecycle.PhasedShutdown, applicationAvailability *availability.ApplicationAvailability) {
+			phasedShutdown.Register(lifecycle.PHASE_DATASOURCE, "datasource", func(context.Context) error {
+				stopped = append(stopped, "datasource")
+				return nil
+			})
+			phasedShutdown.Register(lifecycle.PHASE_WEB_SERVER, "http", func(context.Context) error {
+				readinessWhileStopping = applicationAvailability.GetReadinessState()
+				stopped = append(stopped, "http")
+				return nil
+			})
+			lc.Append(fx.StopHook(func() { stopped = append(stopped, "worker") }))
+		}),
+	)
+
+	start := time.Now()
+	code, _ := app.runApplication(fxApp, v, start)
+
+	if code != 0 {
+		t.Errorf("Unexpected exit code %v", code)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Errorf("The pre-stop delay was not applied")
+	}
+	if readinessWhileStopping != availability.REFUSING_TRAFFIC {
+		t.Errorf("Readiness should be refusing traffic while stopping, got %v", readinessWhileStopping)
+	}
+	if !slices.Equal(stopped, []string{"http", "worker", "datasource"}) {
+		t.Errorf("Unexpected stop order %v", stopped)
+	}
+}
@@ -0,0 +1,46 @@
+package goboot
+
+import (
+	"time"
+
+	"github.com/sjexpos/goboot/environment"
+	"github.com/sjexpos/goboot/event"
+	"github.com/sjexpos/goboot/log"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+// StartedApplication is an application started with GobootApplication.Start, it runs until Stop is called.
+type StartedApplication struct {
+	Environment *viper.Viper
+	Profiles    environment.Profiles
+	app         *GobootApplication
+	fxApp       *fx.App
+}
+
+// Start starts the application without waiting for a shutdown signal and without exiting the process, e.g. to
+// run it in a test (see the goboottest package). When it returns the runners are done and the application is ready.
+func (app *GobootApplication) Start() (*StartedApplication, error) {
+	if app.err != nil {
+		return nil, app.err
+	}
+	start := time.Now()
+	log.MDC.Set(log.GO_ROUTINE_NAME_FIELD_NAME, "main")
+	v, profiles, err := app.prepareEnvironment()
+	if err != nil {
+		app.reportFailure(v, err)
+		return nil, err
+	}
+	fxApp := app.newFxApplication(v, profiles, start)
+	if _, err := app.startApplication(fxApp, v, start); err != nil {
+		return nil, err
+	}
+	return &StartedApplication{Environment: v, Profiles: profiles, app: app, fxApp: fxApp}, nil
+}
+
+// Stop stops the application like a shutdown signal does.
+func (s *StartedApplication) Stop() error {
+	s.app.publishEvent(&event.ContextClosing{})
+	s.app.preStop(s.Environment)
+	return s.app.stopApplication(s.fxApp)
+}
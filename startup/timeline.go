@@ -0,0 +1,96 @@
+package startup
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Kinds of startup steps.
+const (
+	KIND_PROVIDE  = "provide"
+	KIND_DECORATE = "decorate"
+	KIND_INVOKE   = "invoke"
+	KIND_ON_START = "OnStart"
+)
+
+// Step is a constructor, decorator, invoke or OnStart hook which ran while the application started. The duration
+// of an invoke includes the constructors it made run.
+type Step struct {
+	Name     string
+	Kind     string
+	Module   string
+	Types    []string
+	Duration time.Duration
+}
+
+// Timeline records how long each step of the startup took, it is filled by the fx event logger.
+type Timeline struct {
+	mutex     sync.Mutex
+	steps     []Step
+	startTime time.Time
+	timeTaken time.Duration
+}
+
+func NewTimeline(startTime time.Time) *Timeline {
+	return &Timeline{startTime: startTime}
+}
+
+func (t *Timeline) Record(step Step) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.steps = append(t.steps, step)
+}
+
+// Ready marks the end of the startup.
+func (t *Timeline) Ready(readyTime time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.timeTaken = readyTime.Sub(t.startTime)
+}
+
+func (t *Timeline) StartTime() time.Time {
+	return t.startTime
+}
+
+// TimeTaken is the time from the start of the application until it was ready, 0 while it is starting.
+func (t *Timeline) TimeTaken() time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.timeTaken
+}
+
+// Steps returns the recorded steps, the slowest first.
+func (t *Timeline) Steps() []Step {
+	t.mutex.Lock()
+	steps := slices.Clone(t.steps)
+	t.mutex.Unlock()
+	slices.SortStableFunc(steps, func(a, b Step) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	return steps
+}
+
+// String renders the steps as a table, the slowest first.
+func (t *Timeline) String() string {
+	report := strings.Builder{}
+	report.WriteString("\n\n============================\nSTARTUP TIMELINE\n============================\n\n")
+	if timeTaken := t.TimeTaken(); timeTaken > 0 {
+		report.WriteString(fmt.Sprintf("Started in %v\n\n", timeTaken))
+	}
+	table := tabwriter.NewWriter(&report, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "DURATION\tKIND\tMODULE\tNAME")
+	for _, step := range t.Steps() {
+		name := step.Name
+		if len(step.Types) > 0 {
+			name = fmt.Sprintf("%v -> %v", step.Name, strings.Join(step.Types, ", "))
+		}
+		fmt.Fprintf(table, "%v\t%v\t%v\t%v\n", step.Duration, step.Kind, step.Module, name)
+	}
+	table.Flush()
+	return report.String()
+}
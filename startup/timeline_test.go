@@ -0,0 +1,32 @@
+package startup
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeline(t *testing.T) {
+	start := time.Now()
+	timeline := NewTimeline(start)
+	timeline.Record(Step{Name: "newServer", Kind: KIND_PROVIDE, Module: "web", Types: []string{"*http.Server"}, Duration: 2 * time.Millisecond})
+	timeline.Record(Step{Name: "openDatabase", Kind: KIND_ON_START, Module: "datasource", Duration: 30 * time.Millisecond})
+	timeline.Record(Step{Name: "register", Kind: KIND_INVOKE, Duration: 5 * time.Millisecond})
+	timeline.Ready(start.Add(50 * time.Millisecond))
+
+	steps := timeline.Steps()
+	names := []string{steps[0].Name, steps[1].Name, steps[2].Name}
+	if strings.Join(names, ",") != "openDatabase,register,newServer" {
+		t.Errorf("Steps should be sorted by duration, got %v", names)
+	}
+	if timeline.TimeTaken() != 50*time.Millisecond {
+		t.Errorf("Unexpected time taken %v", timeline.TimeTaken())
+	}
+	report := timeline.String()
+	if !strings.Contains(report, "Started in 50ms") || !strings.Contains(report, "newServer -> *http.Server") {
+		t.Errorf("Unexpected report %v", report)
+	}
+	if strings.Index(report, "openDatabase") > strings.Index(report, "newServer") {
+		t.Errorf("The slowest step should be printed first:\n%v", report)
+	}
+}
@@ -1,32 +1,71 @@
 package supportfx
 
 import (
+	"context"
 	"database/sql"
+	_ "embed"
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/condition"
 	"github.com/sjexpos/goboot/datasource"
+	"github.com/sjexpos/goboot/lifecycle"
+	"github.com/sjexpos/goboot/metadata"
 	"log/slog"
 
 	"go.uber.org/fx"
 )
 
-var DatasourceModule = fx.Module("datasource",
+const datasourceEnabledPropertyName = "datasource.enabled"
+
+// lazyInitializationPropertyName is set with goboot.ApplicationBuilder.WithLazyInitialization, when it is true the
+// datasource and gorm do not connect to the database until the first query.
+const lazyInitializationPropertyName = "goboot.lazy-initialization"
+
+// DatasourceModule provides the *sql.DB configured under datasource, unless datasource.enabled is false.
+var DatasourceModule = condition.Conditional("datasource", datasourceModule,
+	condition.OnProperty(datasourceEnabledPropertyName, "true", true),
+)
+
+//go:embed datasource.yaml
+var datasourceDefaults []byte
+
+func init() {
+	autoconfigure.Register(autoconfigure.DATASOURCE_ORDER, DatasourceModule, condition.OnProperty(datasource.DATASOURCE_PROPERTIES_PREFIX+".host", "", false))
+	autoconfigure.RegisterDefaults("datasource", autoconfigure.DATASOURCE_ORDER, datasourceDefaults)
+	metadata.Register(
+		metadata.Property{Key: datasourceEnabledPropertyName, Type: metadata.TYPE_BOOL, Default: "true", Description: "Whether the datasource is created."},
+		metadata.Property{Key: "datasource.host", Type: metadata.TYPE_STRING, Description: "Host of the PostgreSQL database, the datasource is auto-configured only when it is set."},
+		metadata.Property{Key: "datasource.port", Type: metadata.TYPE_INT, Default: "5432", Description: "Port of the database."},
+		metadata.Property{Key: "datasource.username", Type: metadata.TYPE_STRING, Description: "User of the database."},
+		metadata.Property{Key: "datasource.password", Type: metadata.TYPE_STRING, Description: "Password of the database user."},
+		metadata.Property{Key: "datasource.schema_name", Type: metadata.TYPE_STRING, Description: "Name of the database."},
+		metadata.Property{Key: "datasource.pool.max_idle.connections", Type: metadata.TYPE_INT, Default: "10", Description: "Maximum number of idle connections."},
+		metadata.Property{Key: "datasource.pool.max_open.connections", Type: metadata.TYPE_INT, Default: "100", Description: "Maximum number of open connections."},
+		metadata.Property{Key: "datasource.pool.max_lifetime.connection", Type: metadata.TYPE_DURATION, Default: "1h", Description: "Maximum time a connection is reused."},
+		metadata.Property{Key: "datasource.pool.max_idle_time.connection", Type: metadata.TYPE_DURATION, Default: "30s", Description: "Maximum time a connection stays idle."},
+	)
+}
+
+var datasourceModule = fx.Module("datasource",
 	fx.Provide(
+		ConfigurationProperties[datasource.DatasourceProperties](datasource.DATASOURCE_PROPERTIES_PREFIX),
 		fx.Annotate(
-			datasource.NewDatasource,
-			fx.ParamTags(
-				`name:"datasource.host"`,
-				`name:"datasource.port"`,
-				`name:"datasource.username"`,
-				`name:"datasource.password"`,
-				`name:"datasource.schema_name"`,
-				`name:"datasource.pool.max_idle.connections"`,
-				`name:"datasource.pool.max_open.connections"`,
-				`name:"datasource.pool.max_lifetime.connection"`,
-				`name:"datasource.pool.max_idle_time.connection"`,
-			),
-			fx.OnStop(func(ds *sql.DB) {
-				slog.Info("Database shutdown")
-				ds.Close()
-			}),
+			func(properties *datasource.DatasourceProperties, phasedShutdown *lifecycle.PhasedShutdown, lazy bool) (*sql.DB, error) {
+				newDatasource := datasource.NewDatasourceFromProperties
+				if lazy {
+					newDatasource = datasource.OpenDatasourceFromProperties
+				}
+				ds, err := newDatasource(properties)
+				if err != nil {
+					return nil, err
+				}
+				// the database is closed last, once the servers and the workers have drained
+				phasedShutdown.Register(lifecycle.PHASE_DATASOURCE, "Datasource", func(ctx context.Context) error {
+					slog.Info("Database shutdown")
+					return ds.Close()
+				})
+				return ds, nil
+			},
+			fx.ParamTags(``, ``, `name:"`+lazyInitializationPropertyName+`"`),
 		),
 	),
 )
@@ -0,0 +1,19 @@
+package supportfx
+
+import (
+	"github.com/sjexpos/goboot/event"
+	"go.uber.org/fx"
+)
+
+// AddApplicationListener registers the result of constructor f as a listener of the application events, e.g.
+//
+//	fx.Provide(supportfx.AddApplicationListener(func() event.ApplicationListener {
+//		return event.Listener(func(e *event.ApplicationReady) { ... })
+//	}))
+func AddApplicationListener(f any) any {
+	return fx.Annotate(
+		f,
+		fx.As(new(event.ApplicationListener)),
+		fx.ResultTags(`group:"application-listeners"`),
+	)
+}
@@ -1,16 +1,46 @@
 package supportfx
 
 import (
-	"github.com/sjexpos/goboot/gorm"
+	"database/sql"
+	"time"
+
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/condition"
+	goboot_gorm "github.com/sjexpos/goboot/gorm"
+	"github.com/sjexpos/goboot/metadata"
 
 	"go.uber.org/fx"
+	"gorm.io/gorm"
 )
 
-var GormModule = fx.Module("gorm",
+const gormEnabledPropertyName = "gorm.enabled"
+
+// GormModule provides the *gorm.DB, only when there is a *sql.DB (see DatasourceModule) and gorm.enabled is not false.
+var GormModule = condition.Conditional("gorm", gormModule,
+	condition.OnProperty(gormEnabledPropertyName, "true", true),
+	condition.OnBean[*sql.DB](),
+)
+
+func init() {
+	autoconfigure.Register(autoconfigure.GORM_ORDER, GormModule)
+	metadata.Register(
+		metadata.Property{Key: gormEnabledPropertyName, Type: metadata.TYPE_BOOL, Default: "true", Description: "Whether gorm is configured on the datasource."},
+		metadata.Property{Key: "gorm.open-session-in-view.enabled", Type: metadata.TYPE_BOOL, Default: "true", Description: "Whether each web request gets its own gorm session."},
+		metadata.Property{Key: "gorm.log.level", Type: metadata.TYPE_STRING, Default: "Info", Description: "Level of the SQL logs: Silent, Error, Warn or Info."},
+		metadata.Property{Key: "gorm.query.slow.threshold", Type: metadata.TYPE_DURATION, Default: "3s", Description: "Queries slower than this are logged as warnings."},
+	)
+}
+
+var gormModule = fx.Module("gorm",
 	fx.Provide(
 		fx.Annotate(
-			gorm.NewORM,
-			fx.ParamTags(``, `name:"gorm.log.level"`, `name:"gorm.query.slow.threshold"`),
+			func(sqlDB *sql.DB, logLevel string, slowThreshold time.Duration, lazy bool) (*gorm.DB, error) {
+				if lazy {
+					return goboot_gorm.NewLazyORM(sqlDB, logLevel, slowThreshold)
+				}
+				return goboot_gorm.NewORM(sqlDB, logLevel, slowThreshold)
+			},
+			fx.ParamTags(``, `name:"gorm.log.level"`, `name:"gorm.query.slow.threshold"`, `name:"`+lazyInitializationPropertyName+`"`),
 		),
 	),
 )
@@ -1,29 +1,86 @@
 package supportfx
 
 import (
-	"context"
+	_ "embed"
 	"fmt"
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/availability"
+	"github.com/sjexpos/goboot/beans"
+	"github.com/sjexpos/goboot/buildinfo"
+	"github.com/sjexpos/goboot/condition"
+	"github.com/sjexpos/goboot/environment"
+	"github.com/sjexpos/goboot/lifecycle"
 	"github.com/sjexpos/goboot/management"
+	"github.com/sjexpos/goboot/metadata"
+	"github.com/sjexpos/goboot/startup"
 	"log/slog"
 	"net"
 	"net/http"
 
+	"github.com/spf13/viper"
 	"go.uber.org/fx"
 )
 
-var ManagementModule = fx.Module("management",
+const managementEnabledPropertyName = "management.enabled"
+
+// ManagementModule serves the actuators on management.server.port, unless management.enabled is false
+// or the application runs in batch mode.
+var ManagementModule = condition.Conditional("management", managementModule,
+	condition.OnProperty(managementEnabledPropertyName, "true", true),
+	condition.OnProperty(applicationModePropertyName, "server", true),
+)
+
+//go:embed management.yaml
+var managementDefaults []byte
+
+func init() {
+	autoconfigure.Register(autoconfigure.MANAGEMENT_ORDER, ManagementModule)
+	autoconfigure.RegisterDefaults("management", autoconfigure.MANAGEMENT_ORDER, managementDefaults)
+	metadata.Register(
+		metadata.Property{Key: managementEnabledPropertyName, Type: metadata.TYPE_BOOL, Default: "true", Description: "Whether the management server serves the actuators."},
+		metadata.Property{Key: "management.server.port", Type: metadata.TYPE_INT, Default: "4243", Description: "Port of the management server."},
+		metadata.Property{Key: managementEnvShowSecretsPropertyName, Type: metadata.TYPE_BOOL, Default: "false", Description: "Whether the env and configprops actuators show the values of the passwords, secrets, tokens and keys."},
+	)
+}
+
+const managementEnvShowSecretsPropertyName = "management.env.show-secrets"
+
+type managementServerParams struct {
+	fx.In
+
+	ManagementPort  int `name:"management.server.port"`
+	Availability    *availability.ApplicationAvailability
+	BuildInfo       *buildinfo.BuildInfo
+	Timeline        *startup.Timeline
+	Beans           *beans.Registry
+	Environment     *viper.Viper
+	LiveEnvironment *environment.LiveEnvironment
+	Profiles        environment.Profiles
+	Origins         *environment.PropertyOrigins
+	BoundProperties []*environment.BoundProperties `group:"configuration-properties"`
+}
+
+var managementModule = fx.Module("management",
 	fx.Provide(
 		fx.Private,
 		fx.Annotate(
-			func(managementPort int) *http.Server {
+			func(params managementServerParams) *http.Server {
+				v := params.Environment
+				showSecrets := v.GetBool(managementEnvShowSecretsPropertyName)
 				mux := http.NewServeMux()
+				mux.Handle("/actuator/health/readiness", params.Availability.ReadinessHandler())
+				mux.Handle("/actuator/info", management.NewInfoHandler(v.GetString("application.name"), v.GetString("application.version"), params.BuildInfo))
+				mux.Handle("/actuator/startup", management.NewStartupHandler(params.Timeline))
+				mux.Handle("/actuator/beans", management.NewBeansHandler(params.Beans))
+				mux.Handle("/actuator/beans/graph", management.NewBeansGraphHandler(params.Beans))
+				mux.Handle("/actuator/env", management.NewEnvHandler(params.LiveEnvironment, params.Profiles, params.Origins, showSecrets))
+				mux.Handle("/actuator/configprops", management.NewConfigPropsHandler(params.BoundProperties, params.Origins, showSecrets))
 				mux.Handle("/actuator/", management.NewActuators())
 				return &http.Server{
-					Addr:    fmt.Sprintf(":%v", managementPort),
+					Addr:    fmt.Sprintf(":%v", params.ManagementPort),
 					Handler: mux,
 				}
 			},
-			fx.ParamTags(`name:"management.server.port"`),
 			fx.OnStart(func(server *http.Server) error {
 				ln, err := net.Listen("tcp", server.Addr)
 				if err != nil {
@@ -32,15 +89,15 @@ var ManagementModule = fx.Module("management",
 				go server.Serve(ln)
 				return nil
 			}),
-			fx.OnStop(func(ctx context.Context, srv *http.Server) error {
-				slog.Info("Shutting down Http server")
-				return srv.Shutdown(ctx)
-			}),
 		),
 	),
 	fx.Invoke(
-		func(server *http.Server) {
-			slog.Info(fmt.Sprintf("Management server started on port %v (%v) with context path '%v'", server.Addr, "http", "/"))
-		},
+		fx.Annotate(
+			func(server *http.Server, phasedShutdown *lifecycle.PhasedShutdown, shutdown string) {
+				registerServerShutdown(phasedShutdown, "Management server", server, shutdown)
+				slog.Info(fmt.Sprintf("Management server started on port %v (%v) with context path '%v'", server.Addr, "http", "/"))
+			},
+			fx.ParamTags(``, ``, `name:"server.shutdown"`),
+		),
 	),
 )
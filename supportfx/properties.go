@@ -0,0 +1,54 @@
+package supportfx
+
+import (
+	"github.com/sjexpos/goboot/environment"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+const applicationModePropertyName = "application.mode"
+
+const configurationPropertiesGroup = `group:"configuration-properties"`
+
+// ConfigurationProperties returns a constructor which binds the properties under prefix into a *T,
+// so a module receives all its settings as one value, e.g.
+//
+//	fx.Provide(supportfx.ConfigurationProperties[datasource.DatasourceProperties]("datasource"))
+//
+// The bound properties are listed by the configprops actuator.
+func ConfigurationProperties[T any](prefix string) any {
+	return fx.Annotate(
+		func(v *viper.Viper) (*T, *environment.BoundProperties, error) {
+			properties := new(T)
+			if err := environment.Bind(v, prefix, properties); err != nil {
+				return nil, nil, err
+			}
+			return properties, &environment.BoundProperties{Prefix: prefix, Value: func() any { return properties }}, nil
+		},
+		fx.ResultTags(``, configurationPropertiesGroup),
+	)
+}
+
+// RefreshableConfigurationProperties is like ConfigurationProperties, but it provides an
+// *environment.Refreshable[T] which is bound again when the configuration is reloaded (config.watch.enabled).
+func RefreshableConfigurationProperties[T any](prefix string) any {
+	return fx.Annotate(
+		func(v *viper.Viper) (*environment.Refreshable[T], environment.ConfigChangedListener, *environment.BoundProperties, error) {
+			refreshable, err := environment.NewRefreshable[T](v, prefix)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			return refreshable, refreshable, &environment.BoundProperties{Prefix: prefix, Value: func() any { return refreshable.Get() }}, nil
+		},
+		fx.ResultTags(``, `group:"config-changed-listeners"`, configurationPropertiesGroup),
+	)
+}
+
+// AddConfigChangedListener registers the result of constructor f as a listener of configuration reloads.
+func AddConfigChangedListener(f any) any {
+	return fx.Annotate(
+		f,
+		fx.As(new(environment.ConfigChangedListener)),
+		fx.ResultTags(`group:"config-changed-listeners"`),
+	)
+}
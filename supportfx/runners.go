@@ -0,0 +1,35 @@
+package supportfx
+
+import (
+	"github.com/sjexpos/goboot/runner"
+	"go.uber.org/fx"
+)
+
+// AddCommandLineRunner registers the result of constructor f as a runner called with the raw command-line arguments
+// once the application is started.
+func AddCommandLineRunner(f any) any {
+	return fx.Annotate(
+		f,
+		fx.As(new(runner.CommandLineRunner)),
+		fx.ResultTags(`group:"command-line-runners"`),
+	)
+}
+
+// AddApplicationRunner registers the result of constructor f as a runner called with the parsed command-line
+// arguments once the application is started.
+func AddApplicationRunner(f any) any {
+	return fx.Annotate(
+		f,
+		fx.As(new(runner.ApplicationRunner)),
+		fx.ResultTags(`group:"application-runners"`),
+	)
+}
+
+// AddExitCodeGenerator registers the result of constructor f as a generator of the exit code of a batch application.
+func AddExitCodeGenerator(f any) any {
+	return fx.Annotate(
+		f,
+		fx.As(new(runner.ExitCodeGenerator)),
+		fx.ResultTags(`group:"exit-code-generators"`),
+	)
+}
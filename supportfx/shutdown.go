@@ -0,0 +1,33 @@
+package supportfx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/sjexpos/goboot/lifecycle"
+)
+
+const serverShutdownImmediate = "immediate"
+
+// registerServerShutdown stops server in the web server phase. When server.shutdown is graceful the server
+// stops accepting connections and waits for the active requests, up to the timeout of the phase,
+// when it is immediate the connections are closed right away.
+func registerServerShutdown(phasedShutdown *lifecycle.PhasedShutdown, name string, server *http.Server, shutdown string) {
+	phasedShutdown.Register(lifecycle.PHASE_WEB_SERVER, name, func(ctx context.Context) error {
+		if strings.EqualFold(shutdown, serverShutdownImmediate) {
+			slog.Info(fmt.Sprintf("Shutting down %v", name))
+			return server.Close()
+		}
+		slog.Info(fmt.Sprintf("Commencing graceful shutdown of %v, waiting for active requests to complete", name))
+		if err := server.Shutdown(ctx); err != nil {
+			slog.Warn(fmt.Sprintf("Graceful shutdown of %v aborted with active requests", name))
+			server.Close()
+			return err
+		}
+		slog.Info(fmt.Sprintf("Graceful shutdown of %v complete", name))
+		return nil
+	})
+}
@@ -1,16 +1,28 @@
 package supportfx
 
 import (
-	"github.com/sjexpos/goboot/gorm"
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/condition"
+	goboot_gorm "github.com/sjexpos/goboot/gorm"
 	"github.com/sjexpos/goboot/tx"
 
 	"go.uber.org/fx"
+	"gorm.io/gorm"
 )
 
-var TXModule = fx.Module("tx",
+// TXModule provides the transaction manager, only when there is a *gorm.DB (see GormModule).
+var TXModule = condition.Conditional("tx", txModule,
+	condition.OnBean[*gorm.DB](),
+)
+
+func init() {
+	autoconfigure.Register(autoconfigure.TX_ORDER, TXModule)
+}
+
+var txModule = fx.Module("tx",
 	fx.Provide(
 		tx.NewTransactionManager,
 		tx.NewTransactionTemplate,
-		gorm.NewEntityManager,
+		goboot_gorm.NewEntityManager,
 	),
 )
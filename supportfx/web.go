@@ -1,7 +1,7 @@
 package supportfx
 
 import (
-	"context"
+	_ "embed"
 	"fmt"
 	"log/slog"
 	"net"
@@ -9,8 +9,12 @@ import (
 	"sort"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sjexpos/goboot/autoconfigure"
+	"github.com/sjexpos/goboot/condition"
 	"github.com/sjexpos/goboot/core"
 	goboot_gorm "github.com/sjexpos/goboot/gorm"
+	"github.com/sjexpos/goboot/lifecycle"
+	"github.com/sjexpos/goboot/metadata"
 	"github.com/sjexpos/goboot/openapiv3"
 	"github.com/sjexpos/goboot/swaggerui"
 	"github.com/sjexpos/goboot/web"
@@ -21,7 +25,36 @@ import (
 	"gorm.io/gorm"
 )
 
-var WebModule = fx.Module("web",
+const serverEnabledPropertyName = "server.enabled"
+
+// WebModule serves gin and fizz on server.port, unless server.enabled is false, the application runs in batch mode
+// or it provides its own *http.Server.
+var WebModule = condition.Conditional("web", webModule,
+	condition.OnProperty(serverEnabledPropertyName, "true", true),
+	condition.OnProperty(applicationModePropertyName, "server", true),
+	condition.OnMissingBean[*http.Server](),
+)
+
+//go:embed web.yaml
+var webDefaults []byte
+
+func init() {
+	autoconfigure.Register(autoconfigure.WEB_ORDER, WebModule)
+	autoconfigure.RegisterDefaults("web", autoconfigure.WEB_ORDER, webDefaults)
+	metadata.Register(
+		metadata.Property{Key: serverEnabledPropertyName, Type: metadata.TYPE_BOOL, Default: "true", Description: "Whether the web server is started."},
+		metadata.Property{Key: "server.port", Type: metadata.TYPE_INT, Default: "4242", Description: "Port of the web server."},
+		metadata.Property{Key: "server.shutdown", Type: metadata.TYPE_STRING, Default: "graceful", Description: "graceful drains the requests in flight when the servers stop, immediate closes the connections."},
+		metadata.Property{Key: "open-api-v3.api-docs.path", Type: metadata.TYPE_STRING, Default: "/api", Description: "Path of the OpenAPI v3 document."},
+		metadata.Property{Key: "open-api-v3.swagger-ui.path", Type: metadata.TYPE_STRING, Default: "/docs", Description: "Path of the Swagger UI."},
+		metadata.Property{Key: openApiV3InfoPropertyName, Type: metadata.TYPE_MAP, Description: "Info object of the OpenAPI document: title, description, version, contact, license..."},
+		metadata.Property{Key: openApiV3ServersPropertyName, Type: metadata.TYPE_LIST, Description: "Servers of the OpenAPI document, with url, description and variables."},
+		metadata.Property{Key: openApiV3SecurityRequirementPropertyName, Type: metadata.TYPE_LIST, Description: "Security requirements of the OpenAPI document."},
+		metadata.Property{Key: openApiV3SecuritySchemesPropertyName, Type: metadata.TYPE_MAP, Description: "Security schemes of the OpenAPI document."},
+	)
+}
+
+var webModule = fx.Module("web",
 	httpModule,
 	ginModule,
 )
@@ -45,16 +78,16 @@ var httpModule = fx.Module("http",
 				go server.Serve(ln)
 				return nil
 			}),
-			fx.OnStop(func(ctx context.Context, srv *http.Server) error {
-				slog.Info("Shutting down Http server")
-				return srv.Shutdown(ctx)
-			}),
 		),
 	),
 	fx.Invoke(
-		func(server *http.Server) {
-			slog.Info(fmt.Sprintf("Http server started on port %v (%v) with context path '%v'", server.Addr, "http", "/"))
-		},
+		fx.Annotate(
+			func(server *http.Server, phasedShutdown *lifecycle.PhasedShutdown, shutdown string) {
+				registerServerShutdown(phasedShutdown, "Http server", server, shutdown)
+				slog.Info(fmt.Sprintf("Http server started on port %v (%v) with context path '%v'", server.Addr, "http", "/"))
+			},
+			fx.ParamTags(``, ``, `name:"server.shutdown"`),
+		),
 	),
 )
 